@@ -0,0 +1,465 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"task-manager-microservice/api/core"
+	taskspb "task-manager-microservice/proto/tasks"
+)
+
+type Client struct {
+	log        *slog.Logger
+	conn       *grpc.ClientConn
+	tasks      taskspb.TasksServiceClient
+	categories taskspb.CategoriesServiceClient
+}
+
+func NewClient(log *slog.Logger, address string) (*Client, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(forwardCredentials),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create tasks client: %w", err)
+	}
+
+	return &Client{
+		log:        log,
+		conn:       conn,
+		tasks:      taskspb.NewTasksServiceClient(conn),
+		categories: taskspb.NewCategoriesServiceClient(conn),
+	}, nil
+}
+
+// forwardCredentials передаёт данные аутентификации клиента gateway в metadata запроса
+func forwardCredentials(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	creds := core.CredentialsFromContext(ctx)
+	if creds.Authorization != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", creds.Authorization)
+	}
+	if creds.APIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", creds.APIKey)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.tasks.Ping(ctx, &emptypb.Empty{})
+	return c.mapErr(err)
+}
+
+// Categories
+
+func (c *Client) CreateCategory(ctx context.Context, name, idempotencyKey string) (core.Category, error) {
+	resp, err := c.categories.CreateCategory(ctx, &taskspb.CreateCategoryRequest{Name: name, IdempotencyKey: idempotencyKey})
+	if err != nil {
+		return core.Category{}, c.mapErr(err)
+	}
+	return categoryFromPB(resp), nil
+}
+
+func (c *Client) GetCategory(ctx context.Context, id int64) (core.Category, error) {
+	resp, err := c.categories.GetCategory(ctx, &taskspb.GetCategoryRequest{Id: id})
+	if err != nil {
+		return core.Category{}, c.mapErr(err)
+	}
+	return categoryFromPB(resp), nil
+}
+
+func (c *Client) ListCategories(ctx context.Context, pageSize int, pageToken string) (core.CategoryPage, error) {
+	resp, err := c.categories.ListCategories(ctx, &taskspb.ListCategoriesRequest{
+		PageSize:  int32(pageSize),
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return core.CategoryPage{}, c.mapErr(err)
+	}
+
+	out := make([]core.Category, 0, len(resp.GetCategories()))
+	for _, cat := range resp.GetCategories() {
+		out = append(out, categoryFromPB(cat))
+	}
+	return core.CategoryPage{Categories: out, NextPageToken: resp.GetNextPageToken()}, nil
+}
+
+func (c *Client) UpdateCategory(ctx context.Context, id int64, p core.CategoryPatch) (core.Category, error) {
+	// без update_mask сервер меняет только заданные поля
+	resp, err := c.categories.UpdateCategory(ctx, &taskspb.UpdateCategoryRequest{
+		Id:          id,
+		Name:        p.Name,
+		Description: p.Description,
+		Color:       p.Color,
+		Icon:        p.Icon,
+		Position:    p.Position,
+	})
+	if err != nil {
+		return core.Category{}, c.mapErr(err)
+	}
+	return categoryFromPB(resp), nil
+}
+
+func (c *Client) DeleteCategory(ctx context.Context, id int64, strategy core.DeleteStrategy, targetID int64) error {
+	req := &taskspb.DeleteCategoryRequest{Id: id, TargetCategoryId: targetID}
+	switch strategy {
+	case core.DeleteOrphan, "":
+		req.Strategy = taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_ORPHAN
+	case core.DeleteReassignTo:
+		req.Strategy = taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_REASSIGN_TO
+	case core.DeleteTasks:
+		req.Strategy = taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_DELETE_TASKS
+	case core.DeleteFailIfNotEmpty:
+		req.Strategy = taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY
+	default:
+		return fmt.Errorf("%w: invalid strategy", core.ErrInvalidArgs)
+	}
+
+	_, err := c.categories.DeleteCategory(ctx, req)
+	return c.mapErr(err)
+}
+
+// Tasks
+
+func (c *Client) CreateTask(ctx context.Context, req core.CreateTaskRequest) (core.Task, error) {
+	prio, err := priorityToPB(req.Priority)
+	if err != nil {
+		return core.Task{}, err
+	}
+
+	pbReq := &taskspb.CreateTaskRequest{
+		Name:           req.Name,
+		Description:    req.Description,
+		Priority:       prio,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+	if req.CategoryID != nil {
+		pbReq.CategoryId = *req.CategoryID
+	}
+	if req.AssigneeID != nil {
+		pbReq.AssigneeId = *req.AssigneeID
+	}
+	if req.DueAt != nil {
+		pbReq.DueAt = timestamppb.New(*req.DueAt)
+	}
+
+	resp, err := c.tasks.CreateTask(ctx, pbReq)
+	if err != nil {
+		return core.Task{}, c.mapErr(err)
+	}
+	return taskFromPB(resp), nil
+}
+
+func (c *Client) GetTask(ctx context.Context, id int64) (core.Task, error) {
+	resp, err := c.tasks.GetTask(ctx, &taskspb.GetTaskRequest{Id: id})
+	if err != nil {
+		return core.Task{}, c.mapErr(err)
+	}
+	return taskFromPB(resp), nil
+}
+
+func (c *Client) ListTasks(ctx context.Context, f core.ListTasksFilter) (core.TaskPage, error) {
+	req := &taskspb.ListTaskRequest{
+		Limit:             int32(f.Limit),
+		Offset:            int32(f.Offset),
+		PageToken:         f.PageToken,
+		IncludeTotalCount: f.WithTotal,
+	}
+
+	if f.Status != nil {
+		st, err := statusToPB(*f.Status)
+		if err != nil {
+			return core.TaskPage{}, err
+		}
+		req.StatusFilter = &taskspb.ListTaskRequest_Status{Status: st}
+	}
+
+	if f.Priority != nil {
+		prio, err := priorityToPB(*f.Priority)
+		if err != nil {
+			return core.TaskPage{}, err
+		}
+		req.PriorityFilter = &taskspb.ListTaskRequest_Priority{Priority: prio}
+	}
+
+	req.Query = f.Query
+	req.Highlight = f.Highlight
+
+	var sort taskspb.TaskSort
+	switch f.Sort {
+	case "":
+		// сервис сам выберет порядок по умолчанию
+	case core.SortCreatedAt:
+		sort = taskspb.TaskSort_TASK_SORT_CREATED_AT_DESC
+		req.Sort = &sort
+	case core.SortPriority:
+		sort = taskspb.TaskSort_TASK_SORT_PRIORITY_DESC
+		req.Sort = &sort
+	case core.SortRelevance:
+		sort = taskspb.TaskSort_TASK_SORT_RELEVANCE
+		req.Sort = &sort
+	default:
+		return core.TaskPage{}, core.ErrInvalidArgs
+	}
+
+	if f.CategoryID != nil {
+		req.CategoryFilter = &taskspb.ListTaskRequest_CategoryId{CategoryId: *f.CategoryID}
+		req.IncludeSubcategories = f.Subcategories
+	} else if f.WithoutCategory {
+		req.CategoryFilter = &taskspb.ListTaskRequest_WithoutCategory{WithoutCategory: true}
+	}
+
+	if f.DueBefore != nil {
+		req.DueBefore = timestamppb.New(*f.DueBefore)
+	}
+	if f.DueAfter != nil {
+		req.DueAfter = timestamppb.New(*f.DueAfter)
+	}
+	req.Overdue = f.Overdue
+
+	if f.AssigneeID != nil {
+		req.AssigneeFilter = &taskspb.ListTaskRequest_AssigneeId{AssigneeId: *f.AssigneeID}
+	} else if f.Unassigned {
+		req.AssigneeFilter = &taskspb.ListTaskRequest_Unassigned{Unassigned: true}
+	}
+	if f.CreatorID != nil {
+		req.CreatorId = *f.CreatorID
+	}
+
+	resp, err := c.tasks.ListTask(ctx, req)
+	if err != nil {
+		return core.TaskPage{}, c.mapErr(err)
+	}
+
+	out := make([]core.Task, 0, len(resp.GetTasks()))
+	for _, t := range resp.GetTasks() {
+		out = append(out, taskFromPB(t))
+	}
+	return core.TaskPage{
+		Tasks:         out,
+		NextPageToken: resp.GetNextPageToken(),
+		TotalCount:    resp.TotalCount,
+		Highlights:    resp.GetHighlights(),
+	}, nil
+}
+
+func (c *Client) UpdateTask(ctx context.Context, id int64, p core.TaskPatch, version int64) (core.Task, error) {
+	req := &taskspb.UpdateTaskRequest{
+		Id:              id,
+		ExpectedVersion: version,
+		CategoryId:      p.CategoryID,
+		Name:            p.Name,
+		Description:     p.Description,
+		UpdateMask:      &fieldmaskpb.FieldMask{},
+	}
+
+	// маска нужна, чтобы отличать снятие срока от отсутствия поля
+	if p.CategoryID != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "category_id")
+	}
+	if p.Name != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
+	}
+	if p.Description != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
+	}
+	if p.Status != nil {
+		st, err := statusToPB(*p.Status)
+		if err != nil {
+			return core.Task{}, err
+		}
+		req.Status = &st
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "status")
+	}
+	if p.Priority != nil {
+		prio, err := priorityToPB(*p.Priority)
+		if err != nil {
+			return core.Task{}, err
+		}
+		req.Priority = &prio
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "priority")
+	}
+	if p.DueAt != nil {
+		if !p.DueAt.IsZero() {
+			req.DueAt = timestamppb.New(*p.DueAt)
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "due_at")
+	}
+
+	resp, err := c.tasks.UpdateTask(ctx, req)
+	if err != nil {
+		return core.Task{}, c.mapErr(err)
+	}
+	return taskFromPB(resp), nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id int64, version int64) error {
+	_, err := c.tasks.DeleteTask(ctx, &taskspb.DeleteTaskRequest{Id: id, ExpectedVersion: version})
+	return c.mapErr(err)
+}
+
+// Helpers
+
+func categoryFromPB(c *taskspb.Category) core.Category {
+	var parentID *int64
+	if c.GetParentId() != 0 {
+		id := c.GetParentId()
+		parentID = &id
+	}
+
+	return core.Category{
+		ID:          c.GetId(),
+		ParentID:    parentID, // 0 => корневая
+		Name:        c.GetName(),
+		Description: c.GetDescription(),
+		Color:       c.GetColor(),
+		Icon:        c.GetIcon(),
+		Position:    c.GetPosition(),
+		CreatedAt:   c.GetCreatedAt().AsTime(),
+	}
+}
+
+func taskFromPB(t *taskspb.Task) core.Task {
+	var catID *int64
+	if t.GetCategoryId() != 0 {
+		id := t.GetCategoryId()
+		catID = &id
+	}
+
+	out := core.Task{
+		ID:          t.GetId(),
+		CategoryID:  catID, // 0 => без категории
+		Name:        t.GetName(),
+		Description: t.GetDescription(),
+		Status:      statusFromPB(t.GetStatus()),
+		Priority:    priorityFromPB(t.GetPriority()),
+		CreatedAt:   t.GetCreatedAt().AsTime(),
+		UpdatedAt:   t.GetUpdatedAt().AsTime(),
+		Version:     t.GetVersion(),
+	}
+	if t.DueAt != nil {
+		due := t.GetDueAt().AsTime()
+		out.DueAt = &due
+	}
+	if t.CompletedAt != nil {
+		completed := t.GetCompletedAt().AsTime()
+		out.CompletedAt = &completed
+	}
+	if t.ArchivedAt != nil {
+		archived := t.GetArchivedAt().AsTime()
+		out.ArchivedAt = &archived
+	}
+	if t.GetCreatorId() != 0 {
+		id := t.GetCreatorId()
+		out.CreatorID = &id
+	}
+	if t.GetAssigneeId() != 0 {
+		id := t.GetAssigneeId()
+		out.AssigneeID = &id
+	}
+
+	return out
+}
+
+func statusToPB(st core.TaskStatus) (taskspb.TaskStatus, error) {
+	switch st {
+	case core.StatusTODO:
+		return taskspb.TaskStatus_TASK_STATUS_TODO, nil
+	case core.StatusInProgress:
+		return taskspb.TaskStatus_TASK_STATUS_IN_PROGRESS, nil
+	case core.StatusDone:
+		return taskspb.TaskStatus_TASK_STATUS_DONE, nil
+	case core.StatusArchived:
+		return taskspb.TaskStatus_TASK_STATUS_ARCHIVED, nil
+	default:
+		return taskspb.TaskStatus_TASK_STATUS_TODO, core.ErrInvalidArgs
+	}
+}
+
+func statusFromPB(st taskspb.TaskStatus) core.TaskStatus {
+	switch st {
+	case taskspb.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return core.StatusInProgress
+	case taskspb.TaskStatus_TASK_STATUS_DONE:
+		return core.StatusDone
+	case taskspb.TaskStatus_TASK_STATUS_ARCHIVED:
+		return core.StatusArchived
+	default:
+		return core.StatusTODO
+	}
+}
+
+func priorityToPB(p core.TaskPriority) (taskspb.TaskPriority, error) {
+	switch p {
+	case "", core.PriorityLow:
+		return taskspb.TaskPriority_TASK_PRIORITY_LOW, nil
+	case core.PriorityMedium:
+		return taskspb.TaskPriority_TASK_PRIORITY_MEDIUM, nil
+	case core.PriorityHigh:
+		return taskspb.TaskPriority_TASK_PRIORITY_HIGH, nil
+	case core.PriorityUrgent:
+		return taskspb.TaskPriority_TASK_PRIORITY_URGENT, nil
+	default:
+		return taskspb.TaskPriority_TASK_PRIORITY_LOW, core.ErrInvalidArgs
+	}
+}
+
+func priorityFromPB(p taskspb.TaskPriority) core.TaskPriority {
+	switch p {
+	case taskspb.TaskPriority_TASK_PRIORITY_MEDIUM:
+		return core.PriorityMedium
+	case taskspb.TaskPriority_TASK_PRIORITY_HIGH:
+		return core.PriorityHigh
+	case taskspb.TaskPriority_TASK_PRIORITY_URGENT:
+		return core.PriorityUrgent
+	default:
+		return core.PriorityLow
+	}
+}
+
+// mapErr - обратное отображение кодов из Server.mapErr tasks-сервиса
+func (c *Client) mapErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("tasks service: %w", err)
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", core.ErrInvalidArgs, st.Message())
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", core.ErrNotFound, st.Message())
+	case codes.AlreadyExists:
+		return fmt.Errorf("%w: %s", core.ErrAlreadyExists, st.Message())
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", core.ErrFailedPrecondition, st.Message())
+	case codes.Aborted:
+		return fmt.Errorf("%w: %s", core.ErrConflict, st.Message())
+	case codes.Unauthenticated:
+		return fmt.Errorf("%w: %s", core.ErrUnauthenticated, st.Message())
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", core.ErrPermissionDenied, st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		c.log.Error("tasks service unavailable", "error", err)
+		return core.ErrUnavailable
+	default:
+		return fmt.Errorf("tasks service: %s: %s", st.Code(), st.Message())
+	}
+}
@@ -0,0 +1,523 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-manager-microservice/api/core"
+	"task-manager-microservice/api/pkg/res"
+)
+
+type Handler struct {
+	log     *slog.Logger
+	client  core.Client
+	timeout time.Duration
+}
+
+func NewHandler(log *slog.Logger, client core.Client, timeout time.Duration) *Handler {
+	return &Handler{log: log, client: client, timeout: timeout}
+}
+
+// Routes собирает http-роутер api gateway
+func (h *Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /ping", h.ping)
+
+	mux.HandleFunc("GET /categories", h.listCategories)
+	mux.HandleFunc("POST /categories", h.createCategory)
+	mux.HandleFunc("GET /categories/{id}", h.getCategory)
+	mux.HandleFunc("PATCH /categories/{id}", h.updateCategory)
+	mux.HandleFunc("DELETE /categories/{id}", h.deleteCategory)
+
+	mux.HandleFunc("GET /tasks", h.listTasks)
+	mux.HandleFunc("POST /tasks", h.createTask)
+	mux.HandleFunc("GET /tasks/{id}", h.getTask)
+	mux.HandleFunc("PATCH /tasks/{id}", h.updateTask)
+	mux.HandleFunc("DELETE /tasks/{id}", h.deleteTask)
+
+	return h.logging(mux)
+}
+
+func (h *Handler) ping(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	if err := h.client.Ping(ctx); err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, map[string]string{"status": "ok"}, http.StatusOK)
+}
+
+// Categories
+
+func (h *Handler) listCategories(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	pageSize, err := queryInt(r.URL.Query().Get("page_size"))
+	if err != nil {
+		res.Error(w, "invalid page_size", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.client.ListCategories(ctx, pageSize, r.URL.Query().Get("page_token"))
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, page, http.StatusOK)
+}
+
+func (h *Handler) createCategory(w http.ResponseWriter, r *http.Request) {
+	var body core.CategoryRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	c, err := h.client.CreateCategory(ctx, body.Name, r.Header.Get("Idempotency-Key"))
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, c, http.StatusCreated)
+}
+
+func (h *Handler) getCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	c, err := h.client.GetCategory(ctx, id)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, c, http.StatusOK)
+}
+
+func (h *Handler) updateCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var body core.CategoryPatch
+	if !decode(w, r, &body) {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	c, err := h.client.UpdateCategory(ctx, id, body)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, c, http.StatusOK)
+}
+
+func (h *Handler) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+
+	strategy := core.DeleteStrategy(q.Get("strategy"))
+	if strategy != "" && !strategy.Valid() {
+		res.Error(w, "invalid strategy", http.StatusBadRequest)
+		return
+	}
+
+	var targetID int64
+	if v := q.Get("target_category_id"); v != "" {
+		var err error
+		if targetID, err = strconv.ParseInt(v, 10, 64); err != nil || targetID <= 0 {
+			res.Error(w, "invalid target_category_id", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	if err := h.client.DeleteCategory(ctx, id, strategy, targetID); err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.NoContent(w)
+}
+
+// Tasks
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request) {
+	f, err := listTasksFilter(r)
+	if err != nil {
+		res.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	page, err := h.client.ListTasks(ctx, f)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.Json(w, page, http.StatusOK)
+}
+
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request) {
+	var body core.CreateTaskRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	body.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	t, err := h.client.CreateTask(ctx, body)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	setETag(w, t)
+	res.Json(w, t, http.StatusCreated)
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	t, err := h.client.GetTask(ctx, id)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	setETag(w, t)
+	res.Json(w, t, http.StatusOK)
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var body core.TaskPatch
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Status != nil && !body.Status.Valid() {
+		res.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+	if body.Priority != nil && !body.Priority.Valid() {
+		res.Error(w, "invalid priority", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatch(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	t, err := h.client.UpdateTask(ctx, id, body, version)
+	if err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	setETag(w, t)
+	res.Json(w, t, http.StatusOK)
+}
+
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.ctx(r)
+	defer cancel()
+
+	if err := h.client.DeleteTask(ctx, id, version); err != nil {
+		h.writeErr(w, err)
+		return
+	}
+	res.NoContent(w)
+}
+
+// Helpers
+
+// ctx - контекст запроса к tasks-сервису с таймаутом и данными аутентификации клиента
+func (h *Handler) ctx(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx := core.WithCredentials(r.Context(), core.Credentials{
+		Authorization: r.Header.Get("Authorization"),
+		APIKey:        r.Header.Get("X-Api-Key"),
+	})
+	return context.WithTimeout(ctx, h.timeout)
+}
+
+func listTasksFilter(r *http.Request) (core.ListTasksFilter, error) {
+	var f core.ListTasksFilter
+	q := r.URL.Query()
+
+	if v := q.Get("status"); v != "" {
+		st := core.TaskStatus(v)
+		if !st.Valid() {
+			return f, errors.New("invalid status")
+		}
+		f.Status = &st
+	}
+
+	if v := q.Get("priority"); v != "" {
+		p := core.TaskPriority(v)
+		if !p.Valid() {
+			return f, errors.New("invalid priority")
+		}
+		f.Priority = &p
+	}
+
+	if v := q.Get("sort"); v != "" {
+		sort := core.TaskSort(v)
+		if sort != core.SortCreatedAt && sort != core.SortPriority && sort != core.SortRelevance {
+			return f, errors.New("invalid sort")
+		}
+		f.Sort = sort
+	}
+
+	if v := q.Get("category_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return f, errors.New("invalid category_id")
+		}
+		f.CategoryID = &id
+	}
+
+	if v := q.Get("subcategories"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid subcategories")
+		}
+		f.Subcategories = b
+	}
+
+	if v := q.Get("without_category"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid without_category")
+		}
+		f.WithoutCategory = b
+	}
+
+	if v := q.Get("assignee_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return f, errors.New("invalid assignee_id")
+		}
+		f.AssigneeID = &id
+	}
+
+	if v := q.Get("unassigned"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid unassigned")
+		}
+		f.Unassigned = b
+	}
+
+	if v := q.Get("creator_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return f, errors.New("invalid creator_id")
+		}
+		f.CreatorID = &id
+	}
+
+	if v := q.Get("due_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, errors.New("invalid due_before")
+		}
+		f.DueBefore = &t
+	}
+
+	if v := q.Get("due_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, errors.New("invalid due_after")
+		}
+		f.DueAfter = &t
+	}
+
+	if v := q.Get("overdue"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid overdue")
+		}
+		f.Overdue = b
+	}
+
+	if v := q.Get("include_total"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid include_total")
+		}
+		f.WithTotal = b
+	}
+
+	if v := q.Get("highlight"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid highlight")
+		}
+		f.Highlight = b
+	}
+
+	f.Query = q.Get("q")
+	f.PageToken = q.Get("page_token")
+
+	var err error
+	if f.Limit, err = queryInt(q.Get("limit")); err != nil {
+		return f, errors.New("invalid limit")
+	}
+	if f.Offset, err = queryInt(q.Get("offset")); err != nil {
+		return f, errors.New("invalid offset")
+	}
+
+	return f, nil
+}
+
+func queryInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		res.Error(w, "invalid id", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// setETag отдаёт версию задачи как ETag
+func setETag(w http.ResponseWriter, t core.Task) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(t.Version, 10)))
+}
+
+// ifMatch разбирает If-Match в ожидаемую версию задачи, 0 => заголовка нет или "*"
+func ifMatch(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, true
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(v, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		res.Error(w, "invalid If-Match", http.StatusBadRequest)
+		return 0, false
+	}
+	return version, true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		res.Error(w, "invalid json body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeErr маппит ошибки клиента в http статусы
+func (h *Handler) writeErr(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, core.ErrInvalidArgs):
+		res.Error(w, trimPrefix(err), http.StatusBadRequest)
+	case errors.Is(err, core.ErrNotFound):
+		res.Error(w, trimPrefix(err), http.StatusNotFound)
+	case errors.Is(err, core.ErrAlreadyExists), errors.Is(err, core.ErrFailedPrecondition):
+		res.Error(w, trimPrefix(err), http.StatusConflict)
+	case errors.Is(err, core.ErrConflict):
+		res.Error(w, trimPrefix(err), http.StatusPreconditionFailed)
+	case errors.Is(err, core.ErrUnauthenticated):
+		res.Error(w, trimPrefix(err), http.StatusUnauthorized)
+	case errors.Is(err, core.ErrPermissionDenied):
+		res.Error(w, trimPrefix(err), http.StatusForbidden)
+	case errors.Is(err, core.ErrUnavailable):
+		res.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		h.log.Error("internal error", "error", err)
+		res.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+// trimPrefix оставляет сообщение tasks-сервиса без префикса ошибки api
+func trimPrefix(err error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, ": "); i >= 0 {
+		return msg[i+2:]
+	}
+	return msg
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (h *Handler) logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		h.log.Debug("http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration", time.Since(start),
+		)
+	})
+}
@@ -0,0 +1,22 @@
+package core
+
+import "context"
+
+// Credentials - данные аутентификации клиента gateway; передаются в tasks-сервис как есть,
+// проверяет их он
+type Credentials struct {
+	Authorization string // "Bearer <jwt>"
+	APIKey        string
+}
+
+type credentialsKey struct{}
+
+func WithCredentials(ctx context.Context, c Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, c)
+}
+
+// CredentialsFromContext возвращает данные аутентификации или пустые, если их нет
+func CredentialsFromContext(ctx context.Context) Credentials {
+	c, _ := ctx.Value(credentialsKey{}).(Credentials)
+	return c
+}
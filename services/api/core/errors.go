@@ -1 +1,15 @@
 package core
+
+import "errors"
+
+// Ошибки tasks-сервиса, смапленные из gRPC кодов
+var (
+	ErrInvalidArgs        = errors.New("invalid arguments")
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrConflict           = errors.New("version conflict")
+	ErrUnavailable        = errors.New("tasks service unavailable")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+)
@@ -1 +1,167 @@
 package core
+
+import "time"
+
+// TaskStatus в JSON передаётся строкой
+type TaskStatus string
+
+const (
+	StatusTODO       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusDone       TaskStatus = "done"
+	StatusArchived   TaskStatus = "archived"
+)
+
+func (st TaskStatus) Valid() bool {
+	switch st {
+	case StatusTODO, StatusInProgress, StatusDone, StatusArchived:
+		return true
+	default:
+		return false
+	}
+}
+
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+func (p TaskPriority) Valid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeleteStrategy - что делать с задачами удаляемой категории
+type DeleteStrategy string
+
+const (
+	DeleteOrphan         DeleteStrategy = "orphan"
+	DeleteReassignTo     DeleteStrategy = "reassign_to" // вместе с target_category_id
+	DeleteTasks          DeleteStrategy = "delete_tasks"
+	DeleteFailIfNotEmpty DeleteStrategy = "fail_if_not_empty"
+)
+
+func (s DeleteStrategy) Valid() bool {
+	switch s {
+	case DeleteOrphan, DeleteReassignTo, DeleteTasks, DeleteFailIfNotEmpty:
+		return true
+	default:
+		return false
+	}
+}
+
+// TaskSort - порядок выдачи списка задач
+type TaskSort string
+
+const (
+	SortCreatedAt TaskSort = "created_at"
+	SortPriority  TaskSort = "priority"
+	SortRelevance TaskSort = "relevance" // только вместе с q
+)
+
+type Task struct {
+	ID          int64        `json:"id"`
+	CategoryID  *int64       `json:"category_id"` // null без категории
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status"`
+	Priority    TaskPriority `json:"priority"`
+	DueAt       *time.Time   `json:"due_at"` // null без срока
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	CompletedAt *time.Time   `json:"completed_at"`
+	ArchivedAt  *time.Time   `json:"archived_at"`
+	Version     int64        `json:"version"`     // он же ETag
+	CreatorID   *int64       `json:"creator_id"`  // null, если автор неизвестен
+	AssigneeID  *int64       `json:"assignee_id"` // null => не назначена
+}
+
+type Category struct {
+	ID          int64     `json:"id"`
+	ParentID    *int64    `json:"parent_id"` // null у корневой категории
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	Icon        string    `json:"icon"`
+	Position    int32     `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateTaskRequest struct {
+	CategoryID  *int64       `json:"category_id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Priority    TaskPriority `json:"priority"` // пусто => low
+	DueAt       *time.Time   `json:"due_at"`
+	AssigneeID  *int64       `json:"assignee_id"`
+
+	IdempotencyKey string `json:"-"` // из заголовка Idempotency-Key
+}
+
+// TaskPatch - nil поле не меняется, category_id = 0 снимает категорию,
+// due_at = "0001-01-01T00:00:00Z" снимает срок
+type TaskPatch struct {
+	CategoryID  *int64        `json:"category_id"`
+	Name        *string       `json:"name"`
+	Description *string       `json:"description"`
+	Status      *TaskStatus   `json:"status"`
+	Priority    *TaskPriority `json:"priority"`
+	DueAt       *time.Time    `json:"due_at"`
+}
+
+type ListTasksFilter struct {
+	Status          *TaskStatus
+	Priority        *TaskPriority
+	CategoryID      *int64
+	Subcategories   bool // вместе с CategoryID: и во вложенных категориях
+	WithoutCategory bool
+	DueBefore       *time.Time
+	DueAfter        *time.Time
+	Overdue         bool
+	AssigneeID      *int64
+	Unassigned      bool
+	CreatorID       *int64
+	Sort            TaskSort // пусто => created_at, а с q - relevance
+	Query           string
+	Highlight       bool
+	PageToken       string
+	WithTotal       bool
+	Limit           int
+	Offset          int
+}
+
+type TaskPage struct {
+	Tasks         []Task `json:"tasks"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	TotalCount    *int64 `json:"total_count,omitempty"`
+
+	// task id => фрагмент с совпадениями, только с highlight
+	Highlights map[int64]string `json:"highlights,omitempty"`
+}
+
+type CategoryPage struct {
+	Categories    []Category `json:"categories"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+type CategoryRequest struct {
+	Name string `json:"name"`
+}
+
+// CategoryPatch - PATCH категории: меняются только присланные поля,
+// пустая строка в description, color или icon очищает поле
+type CategoryPatch struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+	Position    *int32  `json:"position"`
+}
@@ -1 +1,29 @@
 package core
+
+import "context"
+
+type CategoriesClient interface {
+	// idempotencyKey != "" => повтор с тем же ключом вернёт ту же категорию
+	CreateCategory(ctx context.Context, name, idempotencyKey string) (Category, error)
+	GetCategory(ctx context.Context, id int64) (Category, error)
+	ListCategories(ctx context.Context, pageSize int, pageToken string) (CategoryPage, error)
+	UpdateCategory(ctx context.Context, id int64, p CategoryPatch) (Category, error)
+	DeleteCategory(ctx context.Context, id int64, strategy DeleteStrategy, targetID int64) error
+}
+
+type TasksClient interface {
+	CreateTask(ctx context.Context, req CreateTaskRequest) (Task, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
+	ListTasks(ctx context.Context, f ListTasksFilter) (TaskPage, error)
+	// version != 0 должна совпасть с текущей версией задачи, иначе ErrConflict
+	UpdateTask(ctx context.Context, id int64, p TaskPatch, version int64) (Task, error)
+	DeleteTask(ctx context.Context, id int64, version int64) error
+}
+
+// Client - клиент tasks-сервиса, через который работает api gateway
+type Client interface {
+	CategoriesClient
+	TasksClient
+
+	Ping(ctx context.Context) error
+}
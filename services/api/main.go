@@ -1,7 +1,101 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tasksclient "task-manager-microservice/api/adapters/grpc"
+	"task-manager-microservice/api/adapters/rest"
+	"task-manager-microservice/api/config"
+)
 
 func main() {
-	fmt.Println("Hello World")
+	// config
+	var configPath string
+	flag.StringVar(&configPath, "config", "config.yaml", "api gateway configuration file")
+	flag.Parse()
+
+	cfg := config.MustLoad(configPath)
+
+	// logger
+	log := mustMakeLogger(cfg.LogLevel)
+
+	if err := run(cfg, log); err != nil {
+		log.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config.Config, log *slog.Logger) error {
+	log.Info("starting api gateway")
+
+	// graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// tasks-service client
+	client, err := tasksclient.NewClient(log, cfg.TasksAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create tasks client: %v", err)
+	}
+	defer func(client *tasksclient.Client) {
+		err := client.Close()
+		if err != nil {
+			log.Error("failed to close tasks client", "error", err)
+		}
+	}(client)
+
+	// http
+	handler := rest.NewHandler(log, client, cfg.Timeout)
+
+	srv := &http.Server{
+		Addr:              cfg.Address,
+		Handler:           handler.Routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		log.Debug("shutting down api gateway")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error("failed to shutdown http server", "error", err)
+		}
+	}()
+
+	log.Info("api gateway is running", "address", cfg.Address, "tasks_address", cfg.TasksAddress)
+
+	// blocking
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %v", err)
+	}
+
+	return nil
+}
+
+func mustMakeLogger(levelStr string) *slog.Logger {
+	var level slog.Level
+	switch levelStr {
+	case "DEBUG":
+		level = slog.LevelDebug
+	case "INFO":
+		level = slog.LevelInfo
+	case "ERROR":
+		level = slog.LevelError
+	default:
+		level = slog.LevelInfo
+	}
+
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	return slog.New(handler)
 }
@@ -1 +1,25 @@
 package res
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ErrorResponse - единый формат ошибки для всех ответов api
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func Json(w http.ResponseWriter, data any, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func Error(w http.ResponseWriter, msg string, statusCode int) {
+	Json(w, ErrorResponse{Error: msg}, statusCode)
+}
+
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,11 +24,134 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// что делать с задачами удаляемой категории
+type CategoryDeleteStrategy int32
+
+const (
+	// задачи остаются без категории
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_ORPHAN CategoryDeleteStrategy = 0
+	// задачи переезжают в target_category_id
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_REASSIGN_TO CategoryDeleteStrategy = 1
+	// задачи уходят в корзину вместе с категорией
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_DELETE_TASKS CategoryDeleteStrategy = 2
+	// FAILED_PRECONDITION, если в категории есть задачи
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY CategoryDeleteStrategy = 3
+)
+
+// Enum value maps for CategoryDeleteStrategy.
+var (
+	CategoryDeleteStrategy_name = map[int32]string{
+		0: "CATEGORY_DELETE_STRATEGY_ORPHAN",
+		1: "CATEGORY_DELETE_STRATEGY_REASSIGN_TO",
+		2: "CATEGORY_DELETE_STRATEGY_DELETE_TASKS",
+		3: "CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY",
+	}
+	CategoryDeleteStrategy_value = map[string]int32{
+		"CATEGORY_DELETE_STRATEGY_ORPHAN":            0,
+		"CATEGORY_DELETE_STRATEGY_REASSIGN_TO":       1,
+		"CATEGORY_DELETE_STRATEGY_DELETE_TASKS":      2,
+		"CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY": 3,
+	}
+)
+
+func (x CategoryDeleteStrategy) Enum() *CategoryDeleteStrategy {
+	p := new(CategoryDeleteStrategy)
+	*p = x
+	return p
+}
+
+func (x CategoryDeleteStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CategoryDeleteStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_categories_proto_enumTypes[0].Descriptor()
+}
+
+func (CategoryDeleteStrategy) Type() protoreflect.EnumType {
+	return &file_proto_tasks_categories_proto_enumTypes[0]
+}
+
+func (x CategoryDeleteStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CategoryDeleteStrategy.Descriptor instead.
+func (CategoryDeleteStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{0}
+}
+
+// роль действует на категорию и все вложенные категории
+type CategoryRole int32
+
+const (
+	CategoryRole_CATEGORY_ROLE_UNSPECIFIED CategoryRole = 0
+	// читает категорию и её задачи
+	CategoryRole_CATEGORY_ROLE_VIEWER CategoryRole = 1
+	// создаёт и меняет задачи
+	CategoryRole_CATEGORY_ROLE_EDITOR CategoryRole = 2
+	// меняет и удаляет категорию, раздаёт роли
+	CategoryRole_CATEGORY_ROLE_OWNER CategoryRole = 3
+)
+
+// Enum value maps for CategoryRole.
+var (
+	CategoryRole_name = map[int32]string{
+		0: "CATEGORY_ROLE_UNSPECIFIED",
+		1: "CATEGORY_ROLE_VIEWER",
+		2: "CATEGORY_ROLE_EDITOR",
+		3: "CATEGORY_ROLE_OWNER",
+	}
+	CategoryRole_value = map[string]int32{
+		"CATEGORY_ROLE_UNSPECIFIED": 0,
+		"CATEGORY_ROLE_VIEWER":      1,
+		"CATEGORY_ROLE_EDITOR":      2,
+		"CATEGORY_ROLE_OWNER":       3,
+	}
+)
+
+func (x CategoryRole) Enum() *CategoryRole {
+	p := new(CategoryRole)
+	*p = x
+	return p
+}
+
+func (x CategoryRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CategoryRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_categories_proto_enumTypes[1].Descriptor()
+}
+
+func (CategoryRole) Type() protoreflect.EnumType {
+	return &file_proto_tasks_categories_proto_enumTypes[1]
+}
+
+func (x CategoryRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CategoryRole.Descriptor instead.
+func (CategoryRole) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{1}
+}
+
 type Category struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// задан только у категорий в корзине
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// 0 => корневая категория
+	ParentId    int64  `protobuf:"varint,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// #rrggbb, пустой => без цвета
+	Color string `protobuf:"bytes,7,opt,name=color,proto3" json:"color,omitempty"`
+	Icon  string `protobuf:"bytes,8,opt,name=icon,proto3" json:"icon,omitempty"`
+	// ручная сортировка, меньше => выше
+	Position      int32 `protobuf:"varint,9,opt,name=position,proto3" json:"position,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,11 +207,61 @@ func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Category) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Category) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Category) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Category) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
 type CreateCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 0 => корневая категория
+	ParentId    int64  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Color       string `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	Icon        string `protobuf:"bytes,5,opt,name=icon,proto3" json:"icon,omitempty"`
+	Position    int32  `protobuf:"varint,6,opt,name=position,proto3" json:"position,omitempty"`
+	// повтор с тем же ключом вернёт ту же категорию, можно передать и в metadata idempotency-key
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateCategoryRequest) Reset() {
@@ -127,6 +301,48 @@ func (x *CreateCategoryRequest) GetName() string {
 	return ""
 }
 
+func (x *CreateCategoryRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateCategoryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *CreateCategoryRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type GetCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -172,9 +388,14 @@ func (x *GetCategoryRequest) GetId() int64 {
 }
 
 type ListCategoriesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size <= 0 => 50, максимум 200
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// посчитать задачи категорий страницы по статусам
+	IncludeTaskCounts bool `protobuf:"varint,3,opt,name=include_task_counts,json=includeTaskCounts,proto3" json:"include_task_counts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListCategoriesRequest) Reset() {
@@ -207,16 +428,118 @@ func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{3}
 }
 
-type ListCategoriesResponse struct {
+func (x *ListCategoriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCategoriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCategoriesRequest) GetIncludeTaskCounts() bool {
+	if x != nil {
+		return x.IncludeTaskCounts
+	}
+	return false
+}
+
+// задачи категории по статусам, без вложенных категорий и корзины
+type CategoryTaskCounts struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	Todo          int64                  `protobuf:"varint,1,opt,name=todo,proto3" json:"todo,omitempty"`
+	InProgress    int64                  `protobuf:"varint,2,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	Done          int64                  `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	Archived      int64                  `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	Total         int64                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryTaskCounts) Reset() {
+	*x = CategoryTaskCounts{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryTaskCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryTaskCounts) ProtoMessage() {}
+
+func (x *CategoryTaskCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryTaskCounts.ProtoReflect.Descriptor instead.
+func (*CategoryTaskCounts) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CategoryTaskCounts) GetTodo() int64 {
+	if x != nil {
+		return x.Todo
+	}
+	return 0
+}
+
+func (x *CategoryTaskCounts) GetInProgress() int64 {
+	if x != nil {
+		return x.InProgress
+	}
+	return 0
+}
+
+func (x *CategoryTaskCounts) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *CategoryTaskCounts) GetArchived() int64 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+func (x *CategoryTaskCounts) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListCategoriesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Categories []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	// пустой => больше страниц нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// category id => счётчики, только при include_task_counts
+	TaskCounts    map[int64]*CategoryTaskCounts `protobuf:"bytes,3,rep,name=task_counts,json=taskCounts,proto3" json:"task_counts,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListCategoriesResponse) Reset() {
 	*x = ListCategoriesResponse{}
-	mi := &file_proto_tasks_categories_proto_msgTypes[4]
+	mi := &file_proto_tasks_categories_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -228,7 +551,7 @@ func (x *ListCategoriesResponse) String() string {
 func (*ListCategoriesResponse) ProtoMessage() {}
 
 func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_categories_proto_msgTypes[4]
+	mi := &file_proto_tasks_categories_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +564,7 @@ func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
 func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{4}
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListCategoriesResponse) GetCategories() []*Category {
@@ -251,17 +574,38 @@ func (x *ListCategoriesResponse) GetCategories() []*Category {
 	return nil
 }
 
+func (x *ListCategoriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListCategoriesResponse) GetTaskCounts() map[int64]*CategoryTaskCounts {
+	if x != nil {
+		return x.TaskCounts
+	}
+	return nil
+}
+
 type UpdateCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// пустая строка => очистить
+	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Color       *string `protobuf:"bytes,4,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	Icon        *string `protobuf:"bytes,5,opt,name=icon,proto3,oneof" json:"icon,omitempty"`
+	Position    *int32  `protobuf:"varint,6,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	// без update_mask обновляются заданные поля
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCategoryRequest) Reset() {
 	*x = UpdateCategoryRequest{}
-	mi := &file_proto_tasks_categories_proto_msgTypes[5]
+	mi := &file_proto_tasks_categories_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -273,7 +617,7 @@ func (x *UpdateCategoryRequest) String() string {
 func (*UpdateCategoryRequest) ProtoMessage() {}
 
 func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_categories_proto_msgTypes[5]
+	mi := &file_proto_tasks_categories_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,7 +630,7 @@ func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{5}
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateCategoryRequest) GetId() int64 {
@@ -297,22 +641,60 @@ func (x *UpdateCategoryRequest) GetId() int64 {
 }
 
 func (x *UpdateCategoryRequest) GetName() string {
-	if x != nil {
-		return x.Name
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetIcon() string {
+	if x != nil && x.Icon != nil {
+		return *x.Icon
 	}
 	return ""
 }
 
+func (x *UpdateCategoryRequest) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Strategy CategoryDeleteStrategy `protobuf:"varint,2,opt,name=strategy,proto3,enum=tasks.v1.CategoryDeleteStrategy" json:"strategy,omitempty"`
+	// только для CATEGORY_DELETE_STRATEGY_REASSIGN_TO
+	TargetCategoryId int64 `protobuf:"varint,3,opt,name=target_category_id,json=targetCategoryId,proto3" json:"target_category_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DeleteCategoryRequest) Reset() {
 	*x = DeleteCategoryRequest{}
-	mi := &file_proto_tasks_categories_proto_msgTypes[6]
+	mi := &file_proto_tasks_categories_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +706,7 @@ func (x *DeleteCategoryRequest) String() string {
 func (*DeleteCategoryRequest) ProtoMessage() {}
 
 func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_categories_proto_msgTypes[6]
+	mi := &file_proto_tasks_categories_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +719,7 @@ func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{6}
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteCategoryRequest) GetId() int64 {
@@ -347,36 +729,771 @@ func (x *DeleteCategoryRequest) GetId() int64 {
 	return 0
 }
 
-var File_proto_tasks_categories_proto protoreflect.FileDescriptor
+func (x *DeleteCategoryRequest) GetStrategy() CategoryDeleteStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_ORPHAN
+}
 
-const file_proto_tasks_categories_proto_rawDesc = "" +
-	"\n" +
-	"\x1cproto/tasks/categories.proto\x12\btasks.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"i\n" +
-	"\bCategory\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
-	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"+\n" +
-	"\x15CreateCategoryRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"$\n" +
-	"\x12GetCategoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x17\n" +
-	"\x15ListCategoriesRequest\"L\n" +
-	"\x16ListCategoriesResponse\x122\n" +
-	"\n" +
-	"categories\x18\x01 \x03(\v2\x12.tasks.v1.CategoryR\n" +
-	"categories\";\n" +
-	"\x15UpdateCategoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"'\n" +
-	"\x15DeleteCategoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id2\xbc\x03\n" +
+func (x *DeleteCategoryRequest) GetTargetCategoryId() int64 {
+	if x != nil {
+		return x.TargetCategoryId
+	}
+	return 0
+}
+
+type RestoreCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryRequest) Reset() {
+	*x = RestoreCategoryRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryRequest) ProtoMessage() {}
+
+func (x *RestoreCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type MoveCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 => сделать корневой
+	ParentId      int64 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryRequest) Reset() {
+	*x = MoveCategoryRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryRequest) ProtoMessage() {}
+
+func (x *MoveCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MoveCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveCategoryRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type GetCategoryTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 => все категории, начиная с корневых
+	RootId        int64 `protobuf:"varint,1,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeRequest) Reset() {
+	*x = GetCategoryTreeRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeRequest) ProtoMessage() {}
+
+func (x *GetCategoryTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCategoryTreeRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+type CategoryNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Children      []*CategoryNode        `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryNode) Reset() {
+	*x = CategoryNode{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryNode) ProtoMessage() {}
+
+func (x *CategoryNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryNode.ProtoReflect.Descriptor instead.
+func (*CategoryNode) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CategoryNode) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *CategoryNode) GetChildren() []*CategoryNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type GetCategoryTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roots         []*CategoryNode        `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeResponse) Reset() {
+	*x = GetCategoryTreeResponse{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeResponse) ProtoMessage() {}
+
+func (x *GetCategoryTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetCategoryTreeResponse) GetRoots() []*CategoryNode {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+type MergeCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceIds     []int64                `protobuf:"varint,1,rep,packed,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCategoriesRequest) Reset() {
+	*x = MergeCategoriesRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoriesRequest) ProtoMessage() {}
+
+func (x *MergeCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*MergeCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MergeCategoriesRequest) GetSourceIds() []int64 {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+func (x *MergeCategoriesRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type MergeCategoriesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Target     *Category              `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	MovedTasks int64                  `protobuf:"varint,2,opt,name=moved_tasks,json=movedTasks,proto3" json:"moved_tasks,omitempty"`
+	// id записи о слиянии
+	MergeId       int64 `protobuf:"varint,3,opt,name=merge_id,json=mergeId,proto3" json:"merge_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCategoriesResponse) Reset() {
+	*x = MergeCategoriesResponse{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoriesResponse) ProtoMessage() {}
+
+func (x *MergeCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*MergeCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MergeCategoriesResponse) GetTarget() *Category {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *MergeCategoriesResponse) GetMovedTasks() int64 {
+	if x != nil {
+		return x.MovedTasks
+	}
+	return 0
+}
+
+func (x *MergeCategoriesResponse) GetMergeId() int64 {
+	if x != nil {
+		return x.MergeId
+	}
+	return 0
+}
+
+type CategoryMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          CategoryRole           `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.v1.CategoryRole" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryMember) Reset() {
+	*x = CategoryMember{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryMember) ProtoMessage() {}
+
+func (x *CategoryMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryMember.ProtoReflect.Descriptor instead.
+func (*CategoryMember) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CategoryMember) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategoryMember) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CategoryMember) GetRole() CategoryRole {
+	if x != nil {
+		return x.Role
+	}
+	return CategoryRole_CATEGORY_ROLE_UNSPECIFIED
+}
+
+func (x *CategoryMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SetCategoryMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          CategoryRole           `protobuf:"varint,3,opt,name=role,proto3,enum=tasks.v1.CategoryRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCategoryMemberRequest) Reset() {
+	*x = SetCategoryMemberRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCategoryMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCategoryMemberRequest) ProtoMessage() {}
+
+func (x *SetCategoryMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCategoryMemberRequest.ProtoReflect.Descriptor instead.
+func (*SetCategoryMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetCategoryMemberRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *SetCategoryMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetCategoryMemberRequest) GetRole() CategoryRole {
+	if x != nil {
+		return x.Role
+	}
+	return CategoryRole_CATEGORY_ROLE_UNSPECIFIED
+}
+
+type RemoveCategoryMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCategoryMemberRequest) Reset() {
+	*x = RemoveCategoryMemberRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCategoryMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCategoryMemberRequest) ProtoMessage() {}
+
+func (x *RemoveCategoryMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCategoryMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCategoryMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveCategoryMemberRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *RemoveCategoryMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListCategoryMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryMembersRequest) Reset() {
+	*x = ListCategoryMembersRequest{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryMembersRequest) ProtoMessage() {}
+
+func (x *ListCategoryMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoryMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListCategoryMembersRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+type ListCategoryMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*CategoryMember      `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryMembersResponse) Reset() {
+	*x = ListCategoryMembersResponse{}
+	mi := &file_proto_tasks_categories_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryMembersResponse) ProtoMessage() {}
+
+func (x *ListCategoryMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_categories_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoryMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_categories_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCategoryMembersResponse) GetMembers() []*CategoryMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+var File_proto_tasks_categories_proto protoreflect.FileDescriptor
+
+const file_proto_tasks_categories_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/tasks/categories.proto\x12\btasks.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\xa9\x02\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\x03R\bparentId\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05color\x18\a \x01(\tR\x05color\x12\x12\n" +
+	"\x04icon\x18\b \x01(\tR\x04icon\x12\x1a\n" +
+	"\bposition\x18\t \x01(\x05R\bposition\"\xd9\x01\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05color\x18\x04 \x01(\tR\x05color\x12\x12\n" +
+	"\x04icon\x18\x05 \x01(\tR\x04icon\x12\x1a\n" +
+	"\bposition\x18\x06 \x01(\x05R\bposition\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x83\x01\n" +
+	"\x15ListCategoriesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12.\n" +
+	"\x13include_task_counts\x18\x03 \x01(\bR\x11includeTaskCounts\"\x8f\x01\n" +
+	"\x12CategoryTaskCounts\x12\x12\n" +
+	"\x04todo\x18\x01 \x01(\x03R\x04todo\x12\x1f\n" +
+	"\vin_progress\x18\x02 \x01(\x03R\n" +
+	"inProgress\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\x03R\x04done\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\x03R\barchived\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x03R\x05total\"\xa4\x02\n" +
+	"\x16ListCategoriesResponse\x122\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x12.tasks.v1.CategoryR\n" +
+	"categories\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12Q\n" +
+	"\vtask_counts\x18\x03 \x03(\v20.tasks.v1.ListCategoriesResponse.TaskCountsEntryR\n" +
+	"taskCounts\x1a[\n" +
+	"\x0fTaskCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.tasks.v1.CategoryTaskCountsR\x05value:\x028\x01\"\xb2\x02\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x04 \x01(\tH\x02R\x05color\x88\x01\x01\x12\x17\n" +
+	"\x04icon\x18\x05 \x01(\tH\x03R\x04icon\x88\x01\x01\x12\x1f\n" +
+	"\bposition\x18\x06 \x01(\x05H\x04R\bposition\x88\x01\x01\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_colorB\a\n" +
+	"\x05_iconB\v\n" +
+	"\t_position\"\x93\x01\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12<\n" +
+	"\bstrategy\x18\x02 \x01(\x0e2 .tasks.v1.CategoryDeleteStrategyR\bstrategy\x12,\n" +
+	"\x12target_category_id\x18\x03 \x01(\x03R\x10targetCategoryId\"(\n" +
+	"\x16RestoreCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"B\n" +
+	"\x13MoveCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\"1\n" +
+	"\x16GetCategoryTreeRequest\x12\x17\n" +
+	"\aroot_id\x18\x01 \x01(\x03R\x06rootId\"r\n" +
+	"\fCategoryNode\x12.\n" +
+	"\bcategory\x18\x01 \x01(\v2\x12.tasks.v1.CategoryR\bcategory\x122\n" +
+	"\bchildren\x18\x02 \x03(\v2\x16.tasks.v1.CategoryNodeR\bchildren\"G\n" +
+	"\x17GetCategoryTreeResponse\x12,\n" +
+	"\x05roots\x18\x01 \x03(\v2\x16.tasks.v1.CategoryNodeR\x05roots\"T\n" +
+	"\x16MergeCategoriesRequest\x12\x1d\n" +
+	"\n" +
+	"source_ids\x18\x01 \x03(\x03R\tsourceIds\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\"\x81\x01\n" +
+	"\x17MergeCategoriesResponse\x12*\n" +
+	"\x06target\x18\x01 \x01(\v2\x12.tasks.v1.CategoryR\x06target\x12\x1f\n" +
+	"\vmoved_tasks\x18\x02 \x01(\x03R\n" +
+	"movedTasks\x12\x19\n" +
+	"\bmerge_id\x18\x03 \x01(\x03R\amergeId\"\xb1\x01\n" +
+	"\x0eCategoryMember\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.tasks.v1.CategoryRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x80\x01\n" +
+	"\x18SetCategoryMemberRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.tasks.v1.CategoryRoleR\x04role\"W\n" +
+	"\x1bRemoveCategoryMemberRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"=\n" +
+	"\x1aListCategoryMembersRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\"Q\n" +
+	"\x1bListCategoryMembersResponse\x122\n" +
+	"\amembers\x18\x01 \x03(\v2\x18.tasks.v1.CategoryMemberR\amembers*\xc2\x01\n" +
+	"\x16CategoryDeleteStrategy\x12#\n" +
+	"\x1fCATEGORY_DELETE_STRATEGY_ORPHAN\x10\x00\x12(\n" +
+	"$CATEGORY_DELETE_STRATEGY_REASSIGN_TO\x10\x01\x12)\n" +
+	"%CATEGORY_DELETE_STRATEGY_DELETE_TASKS\x10\x02\x12.\n" +
+	"*CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY\x10\x03*z\n" +
+	"\fCategoryRole\x12\x1d\n" +
+	"\x19CATEGORY_ROLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CATEGORY_ROLE_VIEWER\x10\x01\x12\x18\n" +
+	"\x14CATEGORY_ROLE_EDITOR\x10\x02\x12\x17\n" +
+	"\x13CATEGORY_ROLE_OWNER\x10\x032\x86\b\n" +
 	"\x11CategoriesService\x12E\n" +
 	"\x0eCreateCategory\x12\x1f.tasks.v1.CreateCategoryRequest\x1a\x12.tasks.v1.Category\x12?\n" +
 	"\vGetCategory\x12\x1c.tasks.v1.GetCategoryRequest\x1a\x12.tasks.v1.Category\x12S\n" +
 	"\x0eListCategories\x12\x1f.tasks.v1.ListCategoriesRequest\x1a .tasks.v1.ListCategoriesResponse\x12E\n" +
 	"\x0eUpdateCategory\x12\x1f.tasks.v1.UpdateCategoryRequest\x1a\x12.tasks.v1.Category\x12I\n" +
-	"\x0eDeleteCategory\x12\x1f.tasks.v1.DeleteCategoryRequest\x1a\x16.google.protobuf.Empty\x128\n" +
+	"\x0eDeleteCategory\x12\x1f.tasks.v1.DeleteCategoryRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x0fRestoreCategory\x12 .tasks.v1.RestoreCategoryRequest\x1a\x12.tasks.v1.Category\x12A\n" +
+	"\fMoveCategory\x12\x1d.tasks.v1.MoveCategoryRequest\x1a\x12.tasks.v1.Category\x12V\n" +
+	"\x0fGetCategoryTree\x12 .tasks.v1.GetCategoryTreeRequest\x1a!.tasks.v1.GetCategoryTreeResponse\x12V\n" +
+	"\x0fMergeCategories\x12 .tasks.v1.MergeCategoriesRequest\x1a!.tasks.v1.MergeCategoriesResponse\x12Q\n" +
+	"\x11SetCategoryMember\x12\".tasks.v1.SetCategoryMemberRequest\x1a\x18.tasks.v1.CategoryMember\x12U\n" +
+	"\x14RemoveCategoryMember\x12%.tasks.v1.RemoveCategoryMemberRequest\x1a\x16.google.protobuf.Empty\x12b\n" +
+	"\x13ListCategoryMembers\x12$.tasks.v1.ListCategoryMembersRequest\x1a%.tasks.v1.ListCategoryMembersResponse\x128\n" +
 	"\x04Ping\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00B8Z6task-manager-microservice/services/proto/tasks;taskspbb\x06proto3"
 
 var (
@@ -391,38 +1508,83 @@ func file_proto_tasks_categories_proto_rawDescGZIP() []byte {
 	return file_proto_tasks_categories_proto_rawDescData
 }
 
-var file_proto_tasks_categories_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_tasks_categories_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_tasks_categories_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_proto_tasks_categories_proto_goTypes = []any{
-	(*Category)(nil),               // 0: tasks.v1.Category
-	(*CreateCategoryRequest)(nil),  // 1: tasks.v1.CreateCategoryRequest
-	(*GetCategoryRequest)(nil),     // 2: tasks.v1.GetCategoryRequest
-	(*ListCategoriesRequest)(nil),  // 3: tasks.v1.ListCategoriesRequest
-	(*ListCategoriesResponse)(nil), // 4: tasks.v1.ListCategoriesResponse
-	(*UpdateCategoryRequest)(nil),  // 5: tasks.v1.UpdateCategoryRequest
-	(*DeleteCategoryRequest)(nil),  // 6: tasks.v1.DeleteCategoryRequest
-	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),          // 8: google.protobuf.Empty
+	(CategoryDeleteStrategy)(0),         // 0: tasks.v1.CategoryDeleteStrategy
+	(CategoryRole)(0),                   // 1: tasks.v1.CategoryRole
+	(*Category)(nil),                    // 2: tasks.v1.Category
+	(*CreateCategoryRequest)(nil),       // 3: tasks.v1.CreateCategoryRequest
+	(*GetCategoryRequest)(nil),          // 4: tasks.v1.GetCategoryRequest
+	(*ListCategoriesRequest)(nil),       // 5: tasks.v1.ListCategoriesRequest
+	(*CategoryTaskCounts)(nil),          // 6: tasks.v1.CategoryTaskCounts
+	(*ListCategoriesResponse)(nil),      // 7: tasks.v1.ListCategoriesResponse
+	(*UpdateCategoryRequest)(nil),       // 8: tasks.v1.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),       // 9: tasks.v1.DeleteCategoryRequest
+	(*RestoreCategoryRequest)(nil),      // 10: tasks.v1.RestoreCategoryRequest
+	(*MoveCategoryRequest)(nil),         // 11: tasks.v1.MoveCategoryRequest
+	(*GetCategoryTreeRequest)(nil),      // 12: tasks.v1.GetCategoryTreeRequest
+	(*CategoryNode)(nil),                // 13: tasks.v1.CategoryNode
+	(*GetCategoryTreeResponse)(nil),     // 14: tasks.v1.GetCategoryTreeResponse
+	(*MergeCategoriesRequest)(nil),      // 15: tasks.v1.MergeCategoriesRequest
+	(*MergeCategoriesResponse)(nil),     // 16: tasks.v1.MergeCategoriesResponse
+	(*CategoryMember)(nil),              // 17: tasks.v1.CategoryMember
+	(*SetCategoryMemberRequest)(nil),    // 18: tasks.v1.SetCategoryMemberRequest
+	(*RemoveCategoryMemberRequest)(nil), // 19: tasks.v1.RemoveCategoryMemberRequest
+	(*ListCategoryMembersRequest)(nil),  // 20: tasks.v1.ListCategoryMembersRequest
+	(*ListCategoryMembersResponse)(nil), // 21: tasks.v1.ListCategoryMembersResponse
+	nil,                                 // 22: tasks.v1.ListCategoriesResponse.TaskCountsEntry
+	(*timestamppb.Timestamp)(nil),       // 23: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),       // 24: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),               // 25: google.protobuf.Empty
 }
 var file_proto_tasks_categories_proto_depIdxs = []int32{
-	7, // 0: tasks.v1.Category.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: tasks.v1.ListCategoriesResponse.categories:type_name -> tasks.v1.Category
-	1, // 2: tasks.v1.CategoriesService.CreateCategory:input_type -> tasks.v1.CreateCategoryRequest
-	2, // 3: tasks.v1.CategoriesService.GetCategory:input_type -> tasks.v1.GetCategoryRequest
-	3, // 4: tasks.v1.CategoriesService.ListCategories:input_type -> tasks.v1.ListCategoriesRequest
-	5, // 5: tasks.v1.CategoriesService.UpdateCategory:input_type -> tasks.v1.UpdateCategoryRequest
-	6, // 6: tasks.v1.CategoriesService.DeleteCategory:input_type -> tasks.v1.DeleteCategoryRequest
-	8, // 7: tasks.v1.CategoriesService.Ping:input_type -> google.protobuf.Empty
-	0, // 8: tasks.v1.CategoriesService.CreateCategory:output_type -> tasks.v1.Category
-	0, // 9: tasks.v1.CategoriesService.GetCategory:output_type -> tasks.v1.Category
-	4, // 10: tasks.v1.CategoriesService.ListCategories:output_type -> tasks.v1.ListCategoriesResponse
-	0, // 11: tasks.v1.CategoriesService.UpdateCategory:output_type -> tasks.v1.Category
-	8, // 12: tasks.v1.CategoriesService.DeleteCategory:output_type -> google.protobuf.Empty
-	8, // 13: tasks.v1.CategoriesService.Ping:output_type -> google.protobuf.Empty
-	8, // [8:14] is the sub-list for method output_type
-	2, // [2:8] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	23, // 0: tasks.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	23, // 1: tasks.v1.Category.deleted_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: tasks.v1.ListCategoriesResponse.categories:type_name -> tasks.v1.Category
+	22, // 3: tasks.v1.ListCategoriesResponse.task_counts:type_name -> tasks.v1.ListCategoriesResponse.TaskCountsEntry
+	24, // 4: tasks.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 5: tasks.v1.DeleteCategoryRequest.strategy:type_name -> tasks.v1.CategoryDeleteStrategy
+	2,  // 6: tasks.v1.CategoryNode.category:type_name -> tasks.v1.Category
+	13, // 7: tasks.v1.CategoryNode.children:type_name -> tasks.v1.CategoryNode
+	13, // 8: tasks.v1.GetCategoryTreeResponse.roots:type_name -> tasks.v1.CategoryNode
+	2,  // 9: tasks.v1.MergeCategoriesResponse.target:type_name -> tasks.v1.Category
+	1,  // 10: tasks.v1.CategoryMember.role:type_name -> tasks.v1.CategoryRole
+	23, // 11: tasks.v1.CategoryMember.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 12: tasks.v1.SetCategoryMemberRequest.role:type_name -> tasks.v1.CategoryRole
+	17, // 13: tasks.v1.ListCategoryMembersResponse.members:type_name -> tasks.v1.CategoryMember
+	6,  // 14: tasks.v1.ListCategoriesResponse.TaskCountsEntry.value:type_name -> tasks.v1.CategoryTaskCounts
+	3,  // 15: tasks.v1.CategoriesService.CreateCategory:input_type -> tasks.v1.CreateCategoryRequest
+	4,  // 16: tasks.v1.CategoriesService.GetCategory:input_type -> tasks.v1.GetCategoryRequest
+	5,  // 17: tasks.v1.CategoriesService.ListCategories:input_type -> tasks.v1.ListCategoriesRequest
+	8,  // 18: tasks.v1.CategoriesService.UpdateCategory:input_type -> tasks.v1.UpdateCategoryRequest
+	9,  // 19: tasks.v1.CategoriesService.DeleteCategory:input_type -> tasks.v1.DeleteCategoryRequest
+	10, // 20: tasks.v1.CategoriesService.RestoreCategory:input_type -> tasks.v1.RestoreCategoryRequest
+	11, // 21: tasks.v1.CategoriesService.MoveCategory:input_type -> tasks.v1.MoveCategoryRequest
+	12, // 22: tasks.v1.CategoriesService.GetCategoryTree:input_type -> tasks.v1.GetCategoryTreeRequest
+	15, // 23: tasks.v1.CategoriesService.MergeCategories:input_type -> tasks.v1.MergeCategoriesRequest
+	18, // 24: tasks.v1.CategoriesService.SetCategoryMember:input_type -> tasks.v1.SetCategoryMemberRequest
+	19, // 25: tasks.v1.CategoriesService.RemoveCategoryMember:input_type -> tasks.v1.RemoveCategoryMemberRequest
+	20, // 26: tasks.v1.CategoriesService.ListCategoryMembers:input_type -> tasks.v1.ListCategoryMembersRequest
+	25, // 27: tasks.v1.CategoriesService.Ping:input_type -> google.protobuf.Empty
+	2,  // 28: tasks.v1.CategoriesService.CreateCategory:output_type -> tasks.v1.Category
+	2,  // 29: tasks.v1.CategoriesService.GetCategory:output_type -> tasks.v1.Category
+	7,  // 30: tasks.v1.CategoriesService.ListCategories:output_type -> tasks.v1.ListCategoriesResponse
+	2,  // 31: tasks.v1.CategoriesService.UpdateCategory:output_type -> tasks.v1.Category
+	25, // 32: tasks.v1.CategoriesService.DeleteCategory:output_type -> google.protobuf.Empty
+	2,  // 33: tasks.v1.CategoriesService.RestoreCategory:output_type -> tasks.v1.Category
+	2,  // 34: tasks.v1.CategoriesService.MoveCategory:output_type -> tasks.v1.Category
+	14, // 35: tasks.v1.CategoriesService.GetCategoryTree:output_type -> tasks.v1.GetCategoryTreeResponse
+	16, // 36: tasks.v1.CategoriesService.MergeCategories:output_type -> tasks.v1.MergeCategoriesResponse
+	17, // 37: tasks.v1.CategoriesService.SetCategoryMember:output_type -> tasks.v1.CategoryMember
+	25, // 38: tasks.v1.CategoriesService.RemoveCategoryMember:output_type -> google.protobuf.Empty
+	21, // 39: tasks.v1.CategoriesService.ListCategoryMembers:output_type -> tasks.v1.ListCategoryMembersResponse
+	25, // 40: tasks.v1.CategoriesService.Ping:output_type -> google.protobuf.Empty
+	28, // [28:41] is the sub-list for method output_type
+	15, // [15:28] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_tasks_categories_proto_init() }
@@ -430,18 +1592,20 @@ func file_proto_tasks_categories_proto_init() {
 	if File_proto_tasks_categories_proto != nil {
 		return
 	}
+	file_proto_tasks_categories_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tasks_categories_proto_rawDesc), len(file_proto_tasks_categories_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_tasks_categories_proto_goTypes,
 		DependencyIndexes: file_proto_tasks_categories_proto_depIdxs,
+		EnumInfos:         file_proto_tasks_categories_proto_enumTypes,
 		MessageInfos:      file_proto_tasks_categories_proto_msgTypes,
 	}.Build()
 	File_proto_tasks_categories_proto = out.File
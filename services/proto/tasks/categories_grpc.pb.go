@@ -20,12 +20,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CategoriesService_CreateCategory_FullMethodName = "/tasks.v1.CategoriesService/CreateCategory"
-	CategoriesService_GetCategory_FullMethodName    = "/tasks.v1.CategoriesService/GetCategory"
-	CategoriesService_ListCategories_FullMethodName = "/tasks.v1.CategoriesService/ListCategories"
-	CategoriesService_UpdateCategory_FullMethodName = "/tasks.v1.CategoriesService/UpdateCategory"
-	CategoriesService_DeleteCategory_FullMethodName = "/tasks.v1.CategoriesService/DeleteCategory"
-	CategoriesService_Ping_FullMethodName           = "/tasks.v1.CategoriesService/Ping"
+	CategoriesService_CreateCategory_FullMethodName       = "/tasks.v1.CategoriesService/CreateCategory"
+	CategoriesService_GetCategory_FullMethodName          = "/tasks.v1.CategoriesService/GetCategory"
+	CategoriesService_ListCategories_FullMethodName       = "/tasks.v1.CategoriesService/ListCategories"
+	CategoriesService_UpdateCategory_FullMethodName       = "/tasks.v1.CategoriesService/UpdateCategory"
+	CategoriesService_DeleteCategory_FullMethodName       = "/tasks.v1.CategoriesService/DeleteCategory"
+	CategoriesService_RestoreCategory_FullMethodName      = "/tasks.v1.CategoriesService/RestoreCategory"
+	CategoriesService_MoveCategory_FullMethodName         = "/tasks.v1.CategoriesService/MoveCategory"
+	CategoriesService_GetCategoryTree_FullMethodName      = "/tasks.v1.CategoriesService/GetCategoryTree"
+	CategoriesService_MergeCategories_FullMethodName      = "/tasks.v1.CategoriesService/MergeCategories"
+	CategoriesService_SetCategoryMember_FullMethodName    = "/tasks.v1.CategoriesService/SetCategoryMember"
+	CategoriesService_RemoveCategoryMember_FullMethodName = "/tasks.v1.CategoriesService/RemoveCategoryMember"
+	CategoriesService_ListCategoryMembers_FullMethodName  = "/tasks.v1.CategoriesService/ListCategoryMembers"
+	CategoriesService_Ping_FullMethodName                 = "/tasks.v1.CategoriesService/Ping"
 )
 
 // CategoriesServiceClient is the client API for CategoriesService service.
@@ -36,7 +43,22 @@ type CategoriesServiceClient interface {
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*Category, error)
 	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	// переносит категорию в корзину, задачи обрабатываются по strategy
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// возвращает категорию из корзины
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	// переносит категорию под другую категорию, без циклов
+	MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	// категория со всеми вложенными категориями
+	GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error)
+	// переносит задачи source категорий в target и удаляет source категории
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error)
+	// выдаёт пользователю роль в категории или меняет выданную, нужна роль owner
+	SetCategoryMember(ctx context.Context, in *SetCategoryMemberRequest, opts ...grpc.CallOption) (*CategoryMember, error)
+	// отзывает роль, выданную прямо на категорию
+	RemoveCategoryMember(ctx context.Context, in *RemoveCategoryMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// роли, выданные прямо на категорию, без унаследованных
+	ListCategoryMembers(ctx context.Context, in *ListCategoryMembersRequest, opts ...grpc.CallOption) (*ListCategoryMembersResponse, error)
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
@@ -98,6 +120,76 @@ func (c *categoriesServiceClient) DeleteCategory(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *categoriesServiceClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, CategoriesService_RestoreCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, CategoriesService_MoveCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryTreeResponse)
+	err := c.cc.Invoke(ctx, CategoriesService_GetCategoryTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoriesService_MergeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) SetCategoryMember(ctx context.Context, in *SetCategoryMemberRequest, opts ...grpc.CallOption) (*CategoryMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryMember)
+	err := c.cc.Invoke(ctx, CategoriesService_SetCategoryMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) RemoveCategoryMember(ctx context.Context, in *RemoveCategoryMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CategoriesService_RemoveCategoryMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoriesServiceClient) ListCategoryMembers(ctx context.Context, in *ListCategoryMembersRequest, opts ...grpc.CallOption) (*ListCategoryMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoryMembersResponse)
+	err := c.cc.Invoke(ctx, CategoriesService_ListCategoryMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *categoriesServiceClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -116,7 +208,22 @@ type CategoriesServiceServer interface {
 	GetCategory(context.Context, *GetCategoryRequest) (*Category, error)
 	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error)
+	// переносит категорию в корзину, задачи обрабатываются по strategy
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error)
+	// возвращает категорию из корзины
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*Category, error)
+	// переносит категорию под другую категорию, без циклов
+	MoveCategory(context.Context, *MoveCategoryRequest) (*Category, error)
+	// категория со всеми вложенными категориями
+	GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error)
+	// переносит задачи source категорий в target и удаляет source категории
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error)
+	// выдаёт пользователю роль в категории или меняет выданную, нужна роль owner
+	SetCategoryMember(context.Context, *SetCategoryMemberRequest) (*CategoryMember, error)
+	// отзывает роль, выданную прямо на категорию
+	RemoveCategoryMember(context.Context, *RemoveCategoryMemberRequest) (*emptypb.Empty, error)
+	// роли, выданные прямо на категорию, без унаследованных
+	ListCategoryMembers(context.Context, *ListCategoryMembersRequest) (*ListCategoryMembersResponse, error)
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedCategoriesServiceServer()
 }
@@ -143,6 +250,27 @@ func (UnimplementedCategoriesServiceServer) UpdateCategory(context.Context, *Upd
 func (UnimplementedCategoriesServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
 }
+func (UnimplementedCategoriesServiceServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCategory not implemented")
+}
+func (UnimplementedCategoriesServiceServer) MoveCategory(context.Context, *MoveCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCategory not implemented")
+}
+func (UnimplementedCategoriesServiceServer) GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedCategoriesServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedCategoriesServiceServer) SetCategoryMember(context.Context, *SetCategoryMemberRequest) (*CategoryMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCategoryMember not implemented")
+}
+func (UnimplementedCategoriesServiceServer) RemoveCategoryMember(context.Context, *RemoveCategoryMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCategoryMember not implemented")
+}
+func (UnimplementedCategoriesServiceServer) ListCategoryMembers(context.Context, *ListCategoryMembersRequest) (*ListCategoryMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategoryMembers not implemented")
+}
 func (UnimplementedCategoriesServiceServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -257,6 +385,132 @@ func _CategoriesService_DeleteCategory_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoriesService_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_RestoreCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_MoveCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).MoveCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_MoveCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).MoveCategory(ctx, req.(*MoveCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).GetCategoryTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_GetCategoryTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).GetCategoryTree(ctx, req.(*GetCategoryTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_MergeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_SetCategoryMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCategoryMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).SetCategoryMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_SetCategoryMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).SetCategoryMember(ctx, req.(*SetCategoryMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_RemoveCategoryMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCategoryMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).RemoveCategoryMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_RemoveCategoryMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).RemoveCategoryMember(ctx, req.(*RemoveCategoryMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoriesService_ListCategoryMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoriesServiceServer).ListCategoryMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoriesService_ListCategoryMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoriesServiceServer).ListCategoryMembers(ctx, req.(*ListCategoryMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CategoriesService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -302,6 +556,34 @@ var CategoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCategory",
 			Handler:    _CategoriesService_DeleteCategory_Handler,
 		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _CategoriesService_RestoreCategory_Handler,
+		},
+		{
+			MethodName: "MoveCategory",
+			Handler:    _CategoriesService_MoveCategory_Handler,
+		},
+		{
+			MethodName: "GetCategoryTree",
+			Handler:    _CategoriesService_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _CategoriesService_MergeCategories_Handler,
+		},
+		{
+			MethodName: "SetCategoryMember",
+			Handler:    _CategoriesService_SetCategoryMember_Handler,
+		},
+		{
+			MethodName: "RemoveCategoryMember",
+			Handler:    _CategoriesService_RemoveCategoryMember_Handler,
+		},
+		{
+			MethodName: "ListCategoryMembers",
+			Handler:    _CategoriesService_ListCategoryMembers_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _CategoriesService_Ping_Handler,
@@ -76,16 +76,240 @@ func (TaskStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{0}
 }
 
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_LOW    TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_MEDIUM TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_HIGH   TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_URGENT TaskPriority = 3
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_LOW",
+		1: "TASK_PRIORITY_MEDIUM",
+		2: "TASK_PRIORITY_HIGH",
+		3: "TASK_PRIORITY_URGENT",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_LOW":    0,
+		"TASK_PRIORITY_MEDIUM": 1,
+		"TASK_PRIORITY_HIGH":   2,
+		"TASK_PRIORITY_URGENT": 3,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_tasks_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_proto_tasks_tasks_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskSort int32
+
+const (
+	// created_at DESC
+	TaskSort_TASK_SORT_CREATED_AT_DESC TaskSort = 0
+	// priority DESC, затем created_at DESC
+	TaskSort_TASK_SORT_PRIORITY_DESC TaskSort = 1
+	// по релевантности query, только вместе с query
+	TaskSort_TASK_SORT_RELEVANCE TaskSort = 2
+)
+
+// Enum value maps for TaskSort.
+var (
+	TaskSort_name = map[int32]string{
+		0: "TASK_SORT_CREATED_AT_DESC",
+		1: "TASK_SORT_PRIORITY_DESC",
+		2: "TASK_SORT_RELEVANCE",
+	}
+	TaskSort_value = map[string]int32{
+		"TASK_SORT_CREATED_AT_DESC": 0,
+		"TASK_SORT_PRIORITY_DESC":   1,
+		"TASK_SORT_RELEVANCE":       2,
+	}
+)
+
+func (x TaskSort) Enum() *TaskSort {
+	p := new(TaskSort)
+	*p = x
+	return p
+}
+
+func (x TaskSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_tasks_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskSort) Type() protoreflect.EnumType {
+	return &file_proto_tasks_tasks_proto_enumTypes[2]
+}
+
+func (x TaskSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskSort.Descriptor instead.
+func (TaskSort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+type TaskEventType int32
+
+const (
+	TaskEventType_TASK_EVENT_CREATED  TaskEventType = 0
+	TaskEventType_TASK_EVENT_UPDATED  TaskEventType = 1
+	TaskEventType_TASK_EVENT_DELETED  TaskEventType = 2
+	TaskEventType_TASK_EVENT_RESTORED TaskEventType = 3
+	TaskEventType_TASK_EVENT_PURGED   TaskEventType = 4
+)
+
+// Enum value maps for TaskEventType.
+var (
+	TaskEventType_name = map[int32]string{
+		0: "TASK_EVENT_CREATED",
+		1: "TASK_EVENT_UPDATED",
+		2: "TASK_EVENT_DELETED",
+		3: "TASK_EVENT_RESTORED",
+		4: "TASK_EVENT_PURGED",
+	}
+	TaskEventType_value = map[string]int32{
+		"TASK_EVENT_CREATED":  0,
+		"TASK_EVENT_UPDATED":  1,
+		"TASK_EVENT_DELETED":  2,
+		"TASK_EVENT_RESTORED": 3,
+		"TASK_EVENT_PURGED":   4,
+	}
+)
+
+func (x TaskEventType) Enum() *TaskEventType {
+	p := new(TaskEventType)
+	*p = x
+	return p
+}
+
+func (x TaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_tasks_proto_enumTypes[3].Descriptor()
+}
+
+func (TaskEventType) Type() protoreflect.EnumType {
+	return &file_proto_tasks_tasks_proto_enumTypes[3]
+}
+
+func (x TaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEventType.Descriptor instead.
+func (TaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+type BatchMode int32
+
+const (
+	// ошибка любого элемента откатывает весь пакет
+	BatchMode_BATCH_MODE_ALL_OR_NOTHING BatchMode = 0
+	// применяются все элементы без ошибок
+	BatchMode_BATCH_MODE_BEST_EFFORT BatchMode = 1
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_ALL_OR_NOTHING",
+		1: "BATCH_MODE_BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_ALL_OR_NOTHING": 0,
+		"BATCH_MODE_BEST_EFFORT":    1,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_tasks_proto_enumTypes[4].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_proto_tasks_tasks_proto_enumTypes[4]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{4}
+}
+
 type Task struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	// 0 => без категории
-	CategoryId    int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Status        TaskStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.v1.TaskStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CategoryId  int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TaskStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.v1.TaskStatus" json:"status,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// не задан => без срока
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority TaskPriority           `protobuf:"varint,9,opt,name=priority,proto3,enum=tasks.v1.TaskPriority" json:"priority,omitempty"`
+	Tags     []*Tag                 `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 0 => задача верхнего уровня
+	ParentId int64 `protobuf:"varint,11,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// не заданы, пока задача не в Done / Archived
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ArchivedAt  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// задан только у задач в корзине
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// растёт при каждом изменении задачи, начинается с 1
+	Version int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	// 0 => автор неизвестен; пользователь из metadata x-user-id при создании
+	CreatorId int64 `protobuf:"varint,16,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	// 0 => не назначена
+	AssigneeId    int64 `protobuf:"varint,17,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,12 +393,92 @@ func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Task) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_LOW
+}
+
+func (x *Task) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Task) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCreatorId() int64 {
+	if x != nil {
+		return x.CreatorId
+	}
+	return 0
+}
+
+func (x *Task) GetAssigneeId() int64 {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return 0
+}
+
 type CreateTaskRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// 0 => без категории
-	CategoryId    int64  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CategoryId  int64  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// не задан => без срока
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority TaskPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=tasks.v1.TaskPriority" json:"priority,omitempty"`
+	// 0 => задача верхнего уровня
+	ParentId int64 `protobuf:"varint,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// повтор с тем же ключом вернёт ту же задачу, можно передать и в metadata idempotency-key;
+	// в BatchCreateTasks не используется
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// 0 => не назначена
+	AssigneeId    int64 `protobuf:"varint,8,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -225,33 +529,1726 @@ func (x *CreateTaskRequest) GetName() string {
 
 func (x *CreateTaskRequest) GetDescription() string {
 	if x != nil {
-		return x.Description
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_LOW
+}
+
+func (x *CreateTaskRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetAssigneeId() int64 {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return 0
+}
+
+type AssignTaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 0 => без проверки, иначе при несовпадении с текущей версией - ABORTED
+	ExpectedVersion int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AssignTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UnassignTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnassignTaskRequest) Reset() {
+	*x = UnassignTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignTaskRequest) ProtoMessage() {}
+
+func (x *UnassignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnassignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnassignTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UnassignTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Если oneof не выбран не фильтруем по статусу
+	//
+	// Types that are valid to be assigned to StatusFilter:
+	//
+	//	*ListTaskRequest_Status
+	StatusFilter isListTaskRequest_StatusFilter `protobuf_oneof:"status_filter"`
+	// Types that are valid to be assigned to CategoryFilter:
+	//
+	//	*ListTaskRequest_CategoryId
+	//	*ListTaskRequest_WithoutCategory
+	CategoryFilter isListTaskRequest_CategoryFilter `protobuf_oneof:"category_filter"`
+	// limit <= 0 => 50, максимум 200
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// offset нельзя совмещать с page_token
+	Offset int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// due_after <= due_at < due_before
+	DueBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	DueAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	// только просроченные: due_at в прошлом и задача не done/archived
+	Overdue bool `protobuf:"varint,8,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// Types that are valid to be assigned to PriorityFilter:
+	//
+	//	*ListTaskRequest_Priority
+	PriorityFilter isListTaskRequest_PriorityFilter `protobuf_oneof:"priority_filter"`
+	// не задан => created_at DESC, а при заданном query - по релевантности
+	Sort *TaskSort `protobuf:"varint,10,opt,name=sort,proto3,enum=tasks.v1.TaskSort,oneof" json:"sort,omitempty"`
+	// next_page_token из предыдущего ответа, sort и фильтры должны совпадать
+	PageToken         string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IncludeTotalCount bool   `protobuf:"varint,12,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	// полнотекстовый поиск по name и description (синтаксис websearch)
+	Query string `protobuf:"bytes,13,opt,name=query,proto3" json:"query,omitempty"`
+	// вернуть подсвеченные фрагменты в highlights, только вместе с query
+	Highlight bool `protobuf:"varint,14,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	// задачи хотя бы с одним из тегов
+	AnyTagIds []int64 `protobuf:"varint,15,rep,packed,name=any_tag_ids,json=anyTagIds,proto3" json:"any_tag_ids,omitempty"`
+	// задачи со всеми тегами
+	AllTagIds []int64 `protobuf:"varint,16,rep,packed,name=all_tag_ids,json=allTagIds,proto3" json:"all_tag_ids,omitempty"`
+	// вместе с category_id: задачи и во всех вложенных категориях
+	IncludeSubcategories bool `protobuf:"varint,17,opt,name=include_subcategories,json=includeSubcategories,proto3" json:"include_subcategories,omitempty"`
+	// Types that are valid to be assigned to AssigneeFilter:
+	//
+	//	*ListTaskRequest_AssigneeId
+	//	*ListTaskRequest_AssignedToMe
+	//	*ListTaskRequest_Unassigned
+	AssigneeFilter isListTaskRequest_AssigneeFilter `protobuf_oneof:"assignee_filter"`
+	CreatorId      int64                            `protobuf:"varint,21,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTaskRequest) Reset() {
+	*x = ListTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskRequest) ProtoMessage() {}
+
+func (x *ListTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTaskRequest) GetStatusFilter() isListTaskRequest_StatusFilter {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetStatus() TaskStatus {
+	if x != nil {
+		if x, ok := x.StatusFilter.(*ListTaskRequest_Status); ok {
+			return x.Status
+		}
+	}
+	return TaskStatus_TASK_STATUS_TODO
+}
+
+func (x *ListTaskRequest) GetCategoryFilter() isListTaskRequest_CategoryFilter {
+	if x != nil {
+		return x.CategoryFilter
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetCategoryId() int64 {
+	if x != nil {
+		if x, ok := x.CategoryFilter.(*ListTaskRequest_CategoryId); ok {
+			return x.CategoryId
+		}
+	}
+	return 0
+}
+
+func (x *ListTaskRequest) GetWithoutCategory() bool {
+	if x != nil {
+		if x, ok := x.CategoryFilter.(*ListTaskRequest_WithoutCategory); ok {
+			return x.WithoutCategory
+		}
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTaskRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTaskRequest) GetDueBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueBefore
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetDueAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAfter
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetPriorityFilter() isListTaskRequest_PriorityFilter {
+	if x != nil {
+		return x.PriorityFilter
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		if x, ok := x.PriorityFilter.(*ListTaskRequest_Priority); ok {
+			return x.Priority
+		}
+	}
+	return TaskPriority_TASK_PRIORITY_LOW
+}
+
+func (x *ListTaskRequest) GetSort() TaskSort {
+	if x != nil && x.Sort != nil {
+		return *x.Sort
+	}
+	return TaskSort_TASK_SORT_CREATED_AT_DESC
+}
+
+func (x *ListTaskRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTaskRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTaskRequest) GetHighlight() bool {
+	if x != nil {
+		return x.Highlight
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetAnyTagIds() []int64 {
+	if x != nil {
+		return x.AnyTagIds
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetAllTagIds() []int64 {
+	if x != nil {
+		return x.AllTagIds
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetIncludeSubcategories() bool {
+	if x != nil {
+		return x.IncludeSubcategories
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetAssigneeFilter() isListTaskRequest_AssigneeFilter {
+	if x != nil {
+		return x.AssigneeFilter
+	}
+	return nil
+}
+
+func (x *ListTaskRequest) GetAssigneeId() int64 {
+	if x != nil {
+		if x, ok := x.AssigneeFilter.(*ListTaskRequest_AssigneeId); ok {
+			return x.AssigneeId
+		}
+	}
+	return 0
+}
+
+func (x *ListTaskRequest) GetAssignedToMe() bool {
+	if x != nil {
+		if x, ok := x.AssigneeFilter.(*ListTaskRequest_AssignedToMe); ok {
+			return x.AssignedToMe
+		}
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetUnassigned() bool {
+	if x != nil {
+		if x, ok := x.AssigneeFilter.(*ListTaskRequest_Unassigned); ok {
+			return x.Unassigned
+		}
+	}
+	return false
+}
+
+func (x *ListTaskRequest) GetCreatorId() int64 {
+	if x != nil {
+		return x.CreatorId
+	}
+	return 0
+}
+
+type isListTaskRequest_StatusFilter interface {
+	isListTaskRequest_StatusFilter()
+}
+
+type ListTaskRequest_Status struct {
+	Status TaskStatus `protobuf:"varint,1,opt,name=status,proto3,enum=tasks.v1.TaskStatus,oneof"`
+}
+
+func (*ListTaskRequest_Status) isListTaskRequest_StatusFilter() {}
+
+type isListTaskRequest_CategoryFilter interface {
+	isListTaskRequest_CategoryFilter()
+}
+
+type ListTaskRequest_CategoryId struct {
+	CategoryId int64 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof"` // задачи в конкретной категории
+}
+
+type ListTaskRequest_WithoutCategory struct {
+	WithoutCategory bool `protobuf:"varint,3,opt,name=without_category,json=withoutCategory,proto3,oneof"` // только задачи без категории
+}
+
+func (*ListTaskRequest_CategoryId) isListTaskRequest_CategoryFilter() {}
+
+func (*ListTaskRequest_WithoutCategory) isListTaskRequest_CategoryFilter() {}
+
+type isListTaskRequest_PriorityFilter interface {
+	isListTaskRequest_PriorityFilter()
+}
+
+type ListTaskRequest_Priority struct {
+	Priority TaskPriority `protobuf:"varint,9,opt,name=priority,proto3,enum=tasks.v1.TaskPriority,oneof"`
+}
+
+func (*ListTaskRequest_Priority) isListTaskRequest_PriorityFilter() {}
+
+type isListTaskRequest_AssigneeFilter interface {
+	isListTaskRequest_AssigneeFilter()
+}
+
+type ListTaskRequest_AssigneeId struct {
+	AssigneeId int64 `protobuf:"varint,18,opt,name=assignee_id,json=assigneeId,proto3,oneof"`
+}
+
+type ListTaskRequest_AssignedToMe struct {
+	// назначенные пользователю из metadata x-user-id
+	AssignedToMe bool `protobuf:"varint,19,opt,name=assigned_to_me,json=assignedToMe,proto3,oneof"`
+}
+
+type ListTaskRequest_Unassigned struct {
+	Unassigned bool `protobuf:"varint,20,opt,name=unassigned,proto3,oneof"`
+}
+
+func (*ListTaskRequest_AssigneeId) isListTaskRequest_AssigneeFilter() {}
+
+func (*ListTaskRequest_AssignedToMe) isListTaskRequest_AssigneeFilter() {}
+
+func (*ListTaskRequest_Unassigned) isListTaskRequest_AssigneeFilter() {}
+
+type ListTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// пустой => больше страниц нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// задан, только если include_total_count
+	TotalCount *int64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3,oneof" json:"total_count,omitempty"`
+	// task id => фрагмент с совпадениями, выделенными <b></b>
+	Highlights    map[int64]string `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskResponse) Reset() {
+	*x = ListTaskResponse{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskResponse) ProtoMessage() {}
+
+func (x *ListTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTaskResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTaskResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTaskResponse) GetTotalCount() int64 {
+	if x != nil && x.TotalCount != nil {
+		return *x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListTaskResponse) GetHighlights() map[int64]string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 => снять категорию
+	CategoryId  *int64                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Name        *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status      *TaskStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.v1.TaskStatus,oneof" json:"status,omitempty"`
+	UpdateMask  *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// due_at в update_mask без значения => снять срок
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority *TaskPriority          `protobuf:"varint,8,opt,name=priority,proto3,enum=tasks.v1.TaskPriority,oneof" json:"priority,omitempty"`
+	// 0 => сделать задачей верхнего уровня
+	ParentId *int64 `protobuf:"varint,9,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	// 0 => без проверки, иначе при несовпадении с текущей версией - ABORTED
+	ExpectedVersion int64 `protobuf:"varint,10,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStatus() TaskStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return TaskStatus_TASK_STATUS_TODO
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetPriority() TaskPriority {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_LOW
+}
+
+func (x *UpdateTaskRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 => без проверки, иначе при несовпадении с текущей версией - ABORTED
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TagIds        []int64                `protobuf:"varint,2,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddTagsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddTagsRequest) GetTagIds() []int64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TagIds        []int64                `protobuf:"varint,2,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveTagsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveTagsRequest) GetTagIds() []int64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+type ListSubtasksRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ParentId int64                  `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// limit <= 0 => 50, максимум 200
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksRequest) Reset() {
+	*x = ListSubtasksRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksRequest) ProtoMessage() {}
+
+func (x *ListSubtasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksRequest.ProtoReflect.Descriptor instead.
+func (*ListSubtasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListSubtasksRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *ListSubtasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSubtasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSubtasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// пустой => больше страниц нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksResponse) Reset() {
+	*x = ListSubtasksResponse{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksResponse) ProtoMessage() {}
+
+func (x *ListSubtasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksResponse.ProtoReflect.Descriptor instead.
+func (*ListSubtasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListSubtasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListSubtasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetTaskTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskTreeRequest) Reset() {
+	*x = GetTaskTreeRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskTreeRequest) ProtoMessage() {}
+
+func (x *GetTaskTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskTreeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTaskTreeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TaskNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Children      []*TaskNode            `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskNode) Reset() {
+	*x = TaskNode{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskNode) ProtoMessage() {}
+
+func (x *TaskNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskNode.ProtoReflect.Descriptor instead.
+func (*TaskNode) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskNode) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskNode) GetChildren() []*TaskNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type AddDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	BlockedById   int64                  `protobuf:"varint,2,opt,name=blocked_by_id,json=blockedById,proto3" json:"blocked_by_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddDependencyRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddDependencyRequest) GetBlockedById() int64 {
+	if x != nil {
+		return x.BlockedById
+	}
+	return 0
+}
+
+type RemoveDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	BlockedById   int64                  `protobuf:"varint,2,opt,name=blocked_by_id,json=blockedById,proto3" json:"blocked_by_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveDependencyRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveDependencyRequest) GetBlockedById() int64 {
+	if x != nil {
+		return x.BlockedById
+	}
+	return 0
+}
+
+type ListDependenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDependenciesRequest) Reset() {
+	*x = ListDependenciesRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDependenciesRequest) ProtoMessage() {}
+
+func (x *ListDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDependenciesRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListDependenciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// задачи, которые блокируют task_id
+	BlockedBy []*Task `protobuf:"bytes,1,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	// задачи, которые заблокированы task_id
+	Blocking      []*Task `protobuf:"bytes,2,rep,name=blocking,proto3" json:"blocking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDependenciesResponse) Reset() {
+	*x = ListDependenciesResponse{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDependenciesResponse) ProtoMessage() {}
+
+func (x *ListDependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDependenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListDependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDependenciesResponse) GetBlockedBy() []*Task {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+func (x *ListDependenciesResponse) GetBlocking() []*Task {
+	if x != nil {
+		return x.Blocking
+	}
+	return nil
+}
+
+// old_value / new_value - значения поля в JSON, "null" => не было / не стало
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+type TaskEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type   TaskEventType          `protobuf:"varint,3,opt,name=type,proto3,enum=tasks.v1.TaskEventType" json:"type,omitempty"`
+	// пустой => изменение без x-actor
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TaskEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_TASK_EVENT_CREATED
+}
+
+func (x *TaskEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListTaskHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// page_size <= 0 => 50, максимум 200
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskHistoryRequest) Reset() {
+	*x = ListTaskHistoryRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskHistoryRequest) ProtoMessage() {}
+
+func (x *ListTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTaskHistoryRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListTaskHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTaskHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTaskHistoryResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*TaskEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// пустой => больше страниц нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskHistoryResponse) Reset() {
+	*x = ListTaskHistoryResponse{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskHistoryResponse) ProtoMessage() {}
+
+func (x *ListTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTaskHistoryResponse) GetEvents() []*TaskEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListTaskHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// не больше 500 элементов в пакете
+type BatchCreateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CreateTaskRequest   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=tasks.v1.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTasksRequest) Reset() {
+	*x = BatchCreateTasksRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTasksRequest) ProtoMessage() {}
+
+func (x *BatchCreateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BatchCreateTasksRequest) GetItems() []*CreateTaskRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchCreateTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ALL_OR_NOTHING
+}
+
+// update_mask и присутствие полей - как в UpdateTask, для каждого элемента отдельно
+type BatchUpdateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*UpdateTaskRequest   `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=tasks.v1.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTasksRequest) Reset() {
+	*x = BatchUpdateTasksRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTasksRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BatchUpdateTasksRequest) GetItems() []*UpdateTaskRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchUpdateTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
 	}
-	return ""
+	return BatchMode_BATCH_MODE_ALL_OR_NOTHING
 }
 
-type GetTaskRequest struct {
+type BatchDeleteTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=tasks.v1.BatchMode" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_proto_tasks_tasks_proto_msgTypes[2]
+func (x *BatchDeleteTasksRequest) Reset() {
+	*x = BatchDeleteTasksRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *BatchDeleteTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*BatchDeleteTasksRequest) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_tasks_proto_msgTypes[2]
+func (x *BatchDeleteTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,52 +2259,69 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use BatchDeleteTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetTaskRequest) GetId() int64 {
+func (x *BatchDeleteTasksRequest) GetIds() []int64 {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-type ListTaskRequest struct {
+func (x *BatchDeleteTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ALL_OR_NOTHING
+}
+
+// фильтр - как в ListTask; под него должно попадать не больше 500 задач
+type BulkUpdateStatusRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Если oneof не выбран не фильтруем по статусу
-	//
 	// Types that are valid to be assigned to StatusFilter:
 	//
-	//	*ListTaskRequest_Status
-	StatusFilter isListTaskRequest_StatusFilter `protobuf_oneof:"status_filter"`
+	//	*BulkUpdateStatusRequest_FromStatus
+	StatusFilter isBulkUpdateStatusRequest_StatusFilter `protobuf_oneof:"status_filter"`
 	// Types that are valid to be assigned to CategoryFilter:
 	//
-	//	*ListTaskRequest_CategoryId
-	//	*ListTaskRequest_WithoutCategory
-	CategoryFilter isListTaskRequest_CategoryFilter `protobuf_oneof:"category_filter"`
-	Limit          int32                            `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset         int32                            `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	//	*BulkUpdateStatusRequest_CategoryId
+	//	*BulkUpdateStatusRequest_WithoutCategory
+	CategoryFilter       isBulkUpdateStatusRequest_CategoryFilter `protobuf_oneof:"category_filter"`
+	IncludeSubcategories bool                                     `protobuf:"varint,4,opt,name=include_subcategories,json=includeSubcategories,proto3" json:"include_subcategories,omitempty"`
+	// Types that are valid to be assigned to PriorityFilter:
+	//
+	//	*BulkUpdateStatusRequest_Priority
+	PriorityFilter isBulkUpdateStatusRequest_PriorityFilter `protobuf_oneof:"priority_filter"`
+	DueBefore      *timestamppb.Timestamp                   `protobuf:"bytes,6,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	DueAfter       *timestamppb.Timestamp                   `protobuf:"bytes,7,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	Overdue        bool                                     `protobuf:"varint,8,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	AnyTagIds      []int64                                  `protobuf:"varint,9,rep,packed,name=any_tag_ids,json=anyTagIds,proto3" json:"any_tag_ids,omitempty"`
+	AllTagIds      []int64                                  `protobuf:"varint,10,rep,packed,name=all_tag_ids,json=allTagIds,proto3" json:"all_tag_ids,omitempty"`
+	// новый статус; задачи, уже в нём, в ответ не попадают
+	Status        TaskStatus `protobuf:"varint,11,opt,name=status,proto3,enum=tasks.v1.TaskStatus" json:"status,omitempty"`
+	Mode          BatchMode  `protobuf:"varint,12,opt,name=mode,proto3,enum=tasks.v1.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTaskRequest) Reset() {
-	*x = ListTaskRequest{}
-	mi := &file_proto_tasks_tasks_proto_msgTypes[3]
+func (x *BulkUpdateStatusRequest) Reset() {
+	*x = BulkUpdateStatusRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTaskRequest) String() string {
+func (x *BulkUpdateStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTaskRequest) ProtoMessage() {}
+func (*BulkUpdateStatusRequest) ProtoMessage() {}
 
-func (x *ListTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_tasks_proto_msgTypes[3]
+func (x *BulkUpdateStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,114 +2332,189 @@ func (x *ListTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTaskRequest.ProtoReflect.Descriptor instead.
-func (*ListTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use BulkUpdateStatusRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListTaskRequest) GetStatusFilter() isListTaskRequest_StatusFilter {
+func (x *BulkUpdateStatusRequest) GetStatusFilter() isBulkUpdateStatusRequest_StatusFilter {
 	if x != nil {
 		return x.StatusFilter
 	}
 	return nil
 }
 
-func (x *ListTaskRequest) GetStatus() TaskStatus {
+func (x *BulkUpdateStatusRequest) GetFromStatus() TaskStatus {
 	if x != nil {
-		if x, ok := x.StatusFilter.(*ListTaskRequest_Status); ok {
-			return x.Status
+		if x, ok := x.StatusFilter.(*BulkUpdateStatusRequest_FromStatus); ok {
+			return x.FromStatus
 		}
 	}
 	return TaskStatus_TASK_STATUS_TODO
 }
 
-func (x *ListTaskRequest) GetCategoryFilter() isListTaskRequest_CategoryFilter {
+func (x *BulkUpdateStatusRequest) GetCategoryFilter() isBulkUpdateStatusRequest_CategoryFilter {
 	if x != nil {
 		return x.CategoryFilter
 	}
 	return nil
 }
 
-func (x *ListTaskRequest) GetCategoryId() int64 {
+func (x *BulkUpdateStatusRequest) GetCategoryId() int64 {
 	if x != nil {
-		if x, ok := x.CategoryFilter.(*ListTaskRequest_CategoryId); ok {
+		if x, ok := x.CategoryFilter.(*BulkUpdateStatusRequest_CategoryId); ok {
 			return x.CategoryId
 		}
 	}
 	return 0
 }
 
-func (x *ListTaskRequest) GetWithoutCategory() bool {
+func (x *BulkUpdateStatusRequest) GetWithoutCategory() bool {
 	if x != nil {
-		if x, ok := x.CategoryFilter.(*ListTaskRequest_WithoutCategory); ok {
+		if x, ok := x.CategoryFilter.(*BulkUpdateStatusRequest_WithoutCategory); ok {
 			return x.WithoutCategory
 		}
 	}
 	return false
 }
 
-func (x *ListTaskRequest) GetLimit() int32 {
+func (x *BulkUpdateStatusRequest) GetIncludeSubcategories() bool {
 	if x != nil {
-		return x.Limit
+		return x.IncludeSubcategories
 	}
-	return 0
+	return false
 }
 
-func (x *ListTaskRequest) GetOffset() int32 {
+func (x *BulkUpdateStatusRequest) GetPriorityFilter() isBulkUpdateStatusRequest_PriorityFilter {
 	if x != nil {
-		return x.Offset
+		return x.PriorityFilter
 	}
-	return 0
+	return nil
 }
 
-type isListTaskRequest_StatusFilter interface {
-	isListTaskRequest_StatusFilter()
+func (x *BulkUpdateStatusRequest) GetPriority() TaskPriority {
+	if x != nil {
+		if x, ok := x.PriorityFilter.(*BulkUpdateStatusRequest_Priority); ok {
+			return x.Priority
+		}
+	}
+	return TaskPriority_TASK_PRIORITY_LOW
 }
 
-type ListTaskRequest_Status struct {
-	Status TaskStatus `protobuf:"varint,1,opt,name=status,proto3,enum=tasks.v1.TaskStatus,oneof"`
+func (x *BulkUpdateStatusRequest) GetDueBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueBefore
+	}
+	return nil
 }
 
-func (*ListTaskRequest_Status) isListTaskRequest_StatusFilter() {}
+func (x *BulkUpdateStatusRequest) GetDueAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAfter
+	}
+	return nil
+}
 
-type isListTaskRequest_CategoryFilter interface {
-	isListTaskRequest_CategoryFilter()
+func (x *BulkUpdateStatusRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
 }
 
-type ListTaskRequest_CategoryId struct {
-	CategoryId int64 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof"` // задачи в конкретной категории
+func (x *BulkUpdateStatusRequest) GetAnyTagIds() []int64 {
+	if x != nil {
+		return x.AnyTagIds
+	}
+	return nil
 }
 
-type ListTaskRequest_WithoutCategory struct {
-	WithoutCategory bool `protobuf:"varint,3,opt,name=without_category,json=withoutCategory,proto3,oneof"` // только задачи без категории
+func (x *BulkUpdateStatusRequest) GetAllTagIds() []int64 {
+	if x != nil {
+		return x.AllTagIds
+	}
+	return nil
 }
 
-func (*ListTaskRequest_CategoryId) isListTaskRequest_CategoryFilter() {}
+func (x *BulkUpdateStatusRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_TODO
+}
 
-func (*ListTaskRequest_WithoutCategory) isListTaskRequest_CategoryFilter() {}
+func (x *BulkUpdateStatusRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ALL_OR_NOTHING
+}
 
-type ListTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+type isBulkUpdateStatusRequest_StatusFilter interface {
+	isBulkUpdateStatusRequest_StatusFilter()
+}
+
+type BulkUpdateStatusRequest_FromStatus struct {
+	FromStatus TaskStatus `protobuf:"varint,1,opt,name=from_status,json=fromStatus,proto3,enum=tasks.v1.TaskStatus,oneof"`
+}
+
+func (*BulkUpdateStatusRequest_FromStatus) isBulkUpdateStatusRequest_StatusFilter() {}
+
+type isBulkUpdateStatusRequest_CategoryFilter interface {
+	isBulkUpdateStatusRequest_CategoryFilter()
+}
+
+type BulkUpdateStatusRequest_CategoryId struct {
+	CategoryId int64 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof"`
+}
+
+type BulkUpdateStatusRequest_WithoutCategory struct {
+	WithoutCategory bool `protobuf:"varint,3,opt,name=without_category,json=withoutCategory,proto3,oneof"`
+}
+
+func (*BulkUpdateStatusRequest_CategoryId) isBulkUpdateStatusRequest_CategoryFilter() {}
+
+func (*BulkUpdateStatusRequest_WithoutCategory) isBulkUpdateStatusRequest_CategoryFilter() {}
+
+type isBulkUpdateStatusRequest_PriorityFilter interface {
+	isBulkUpdateStatusRequest_PriorityFilter()
+}
+
+type BulkUpdateStatusRequest_Priority struct {
+	Priority TaskPriority `protobuf:"varint,5,opt,name=priority,proto3,enum=tasks.v1.TaskPriority,oneof"`
+}
+
+func (*BulkUpdateStatusRequest_Priority) isBulkUpdateStatusRequest_PriorityFilter() {}
+
+type BatchItemResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 у несозданной задачи
+	TaskId int64 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// код google.golang.org/grpc/codes, OK => элемент применён.
+	// ABORTED => элемент отменён из-за ошибки другого элемента
+	Code  int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// задача после операции, не задана у удаления и при ошибке
+	Task          *Task `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTaskResponse) Reset() {
-	*x = ListTaskResponse{}
-	mi := &file_proto_tasks_tasks_proto_msgTypes[4]
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTaskResponse) String() string {
+func (x *BatchItemResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTaskResponse) ProtoMessage() {}
+func (*BatchItemResult) ProtoMessage() {}
 
-func (x *ListTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_tasks_proto_msgTypes[4]
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,46 +2525,64 @@ func (x *ListTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTaskResponse.ProtoReflect.Descriptor instead.
-func (*ListTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ListTaskResponse) GetTasks() []*Task {
+func (x *BatchItemResult) GetTaskId() int64 {
 	if x != nil {
-		return x.Tasks
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
 	}
 	return nil
 }
 
-type UpdateTaskRequest struct {
+type BatchTasksResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	// 0 => снять категорию
-	CategoryId    *int64                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
-	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Status        *TaskStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.v1.TaskStatus,oneof" json:"status,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// в порядке элементов запроса
+	Results       []*BatchItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Succeeded     int32              `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32              `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskRequest) Reset() {
-	*x = UpdateTaskRequest{}
-	mi := &file_proto_tasks_tasks_proto_msgTypes[5]
+func (x *BatchTasksResponse) Reset() {
+	*x = BatchTasksResponse{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskRequest) String() string {
+func (x *BatchTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskRequest) ProtoMessage() {}
+func (*BatchTasksResponse) ProtoMessage() {}
 
-func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_tasks_proto_msgTypes[5]
+func (x *BatchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,75 +2593,119 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use BatchTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *UpdateTaskRequest) GetId() int64 {
+func (x *BatchTasksResponse) GetResults() []*BatchItemResult {
 	if x != nil {
-		return x.Id
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchTasksResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
 	}
 	return 0
 }
 
-func (x *UpdateTaskRequest) GetCategoryId() int64 {
-	if x != nil && x.CategoryId != nil {
-		return *x.CategoryId
+func (x *BatchTasksResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
 	}
 	return 0
 }
 
-func (x *UpdateTaskRequest) GetName() string {
-	if x != nil && x.Name != nil {
-		return *x.Name
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// изменения задач категории, включая уход задачи из неё
+	CategoryId *int64 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	// изменения задач в статусе, включая уход задачи из него
+	Status *TaskStatus `protobuf:"varint,2,opt,name=status,proto3,enum=tasks.v1.TaskStatus,oneof" json:"status,omitempty"`
+	// 0 => только изменения после подписки, иначе - после события с этим id
+	AfterEventId  int64 `protobuf:"varint,3,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateTaskRequest) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchTasksRequest) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateTaskRequest) GetStatus() TaskStatus {
+func (x *WatchTasksRequest) GetStatus() TaskStatus {
 	if x != nil && x.Status != nil {
 		return *x.Status
 	}
 	return TaskStatus_TASK_STATUS_TODO
 }
 
-func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *WatchTasksRequest) GetAfterEventId() int64 {
 	if x != nil {
-		return x.UpdateMask
+		return x.AfterEventId
 	}
-	return nil
+	return 0
 }
 
-type DeleteTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+type TaskChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Event *TaskEvent             `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// текущее состояние задачи, не задано после окончательного удаления
+	Task          *Task `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskRequest) Reset() {
-	*x = DeleteTaskRequest{}
-	mi := &file_proto_tasks_tasks_proto_msgTypes[6]
+func (x *TaskChange) Reset() {
+	*x = TaskChange{}
+	mi := &file_proto_tasks_tasks_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskRequest) String() string {
+func (x *TaskChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskRequest) ProtoMessage() {}
+func (*TaskChange) ProtoMessage() {}
 
-func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tasks_tasks_proto_msgTypes[6]
+func (x *TaskChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_tasks_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,23 +2716,30 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TaskChange.ProtoReflect.Descriptor instead.
+func (*TaskChange) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_tasks_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *DeleteTaskRequest) GetId() int64 {
+func (x *TaskChange) GetEvent() *TaskEvent {
 	if x != nil {
-		return x.Id
+		return x.Event
 	}
-	return 0
+	return nil
+}
+
+func (x *TaskChange) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
 }
 
 var File_proto_tasks_tasks_proto protoreflect.FileDescriptor
 
 const file_proto_tasks_tasks_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/tasks/tasks.proto\x12\btasks.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\x91\x02\n" +
+	"\x17proto/tasks/tasks.proto\x12\btasks.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x16proto/tasks/tags.proto\"\xc9\x05\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vcategory_id\x18\x02 \x01(\x03R\n" +
@@ -592,25 +2750,89 @@ const file_proto_tasks_tasks_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"j\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x122\n" +
+	"\bpriority\x18\t \x01(\x0e2\x16.tasks.v1.TaskPriorityR\bpriority\x12!\n" +
+	"\x04tags\x18\n" +
+	" \x03(\v2\r.tasks.v1.TagR\x04tags\x12\x1b\n" +
+	"\tparent_id\x18\v \x01(\x03R\bparentId\x12=\n" +
+	"\fcompleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12;\n" +
+	"\varchived_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x10 \x01(\x03R\tcreatorId\x12\x1f\n" +
+	"\vassignee_id\x18\x11 \x01(\x03R\n" +
+	"assigneeId\"\xb8\x02\n" +
 	"\x11CreateTaskRequest\x12\x1f\n" +
 	"\vcategory_id\x18\x01 \x01(\x03R\n" +
 	"categoryId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\" \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x121\n" +
+	"\x06due_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x122\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x16.tasks.v1.TaskPriorityR\bpriority\x12\x1b\n" +
+	"\tparent_id\x18\x06 \x01(\x03R\bparentId\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x12\x1f\n" +
+	"\vassignee_id\x18\b \x01(\x03R\n" +
+	"assigneeId\"g\n" +
+	"\x11AssignTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"P\n" +
+	"\x13UnassignTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\" \n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\xe3\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x87\a\n" +
 	"\x0fListTaskRequest\x12.\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x14.tasks.v1.TaskStatusH\x00R\x06status\x12!\n" +
 	"\vcategory_id\x18\x02 \x01(\x03H\x01R\n" +
 	"categoryId\x12+\n" +
 	"\x10without_category\x18\x03 \x01(\bH\x01R\x0fwithoutCategory\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offsetB\x0f\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x129\n" +
+	"\n" +
+	"due_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdueBefore\x127\n" +
+	"\tdue_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bdueAfter\x12\x18\n" +
+	"\aoverdue\x18\b \x01(\bR\aoverdue\x124\n" +
+	"\bpriority\x18\t \x01(\x0e2\x16.tasks.v1.TaskPriorityH\x02R\bpriority\x12+\n" +
+	"\x04sort\x18\n" +
+	" \x01(\x0e2\x12.tasks.v1.TaskSortH\x04R\x04sort\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12.\n" +
+	"\x13include_total_count\x18\f \x01(\bR\x11includeTotalCount\x12\x14\n" +
+	"\x05query\x18\r \x01(\tR\x05query\x12\x1c\n" +
+	"\thighlight\x18\x0e \x01(\bR\thighlight\x12\x1e\n" +
+	"\vany_tag_ids\x18\x0f \x03(\x03R\tanyTagIds\x12\x1e\n" +
+	"\vall_tag_ids\x18\x10 \x03(\x03R\tallTagIds\x123\n" +
+	"\x15include_subcategories\x18\x11 \x01(\bR\x14includeSubcategories\x12!\n" +
+	"\vassignee_id\x18\x12 \x01(\x03H\x03R\n" +
+	"assigneeId\x12&\n" +
+	"\x0eassigned_to_me\x18\x13 \x01(\bH\x03R\fassignedToMe\x12 \n" +
+	"\n" +
+	"unassigned\x18\x14 \x01(\bH\x03R\n" +
+	"unassigned\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x15 \x01(\x03R\tcreatorIdB\x0f\n" +
 	"\rstatus_filterB\x11\n" +
-	"\x0fcategory_filter\"8\n" +
+	"\x0fcategory_filterB\x11\n" +
+	"\x0fpriority_filterB\x11\n" +
+	"\x0fassignee_filterB\a\n" +
+	"\x05_sort\"\xa1\x02\n" +
 	"\x10ListTaskResponse\x12$\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks\"\xad\x02\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12$\n" +
+	"\vtotal_count\x18\x03 \x01(\x03H\x00R\n" +
+	"totalCount\x88\x01\x01\x12J\n" +
+	"\n" +
+	"highlights\x18\x04 \x03(\v2*.tasks.v1.ListTaskResponse.HighlightsEntryR\n" +
+	"highlights\x1a=\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_total_count\"\x81\x04\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12$\n" +
 	"\vcategory_id\x18\x02 \x01(\x03H\x00R\n" +
@@ -619,19 +2841,148 @@ const file_proto_tasks_tasks_proto_rawDesc = "" +
 	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x121\n" +
 	"\x06status\x18\x05 \x01(\x0e2\x14.tasks.v1.TaskStatusH\x03R\x06status\x88\x01\x01\x12;\n" +
 	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMaskB\x0e\n" +
+	"updateMask\x121\n" +
+	"\x06due_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x127\n" +
+	"\bpriority\x18\b \x01(\x0e2\x16.tasks.v1.TaskPriorityH\x04R\bpriority\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\t \x01(\x03H\x05R\bparentId\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\n" +
+	" \x01(\x03R\x0fexpectedVersionB\x0e\n" +
 	"\f_category_idB\a\n" +
 	"\x05_nameB\x0e\n" +
 	"\f_descriptionB\t\n" +
-	"\a_status\"#\n" +
+	"\a_statusB\v\n" +
+	"\t_priorityB\f\n" +
+	"\n" +
+	"_parent_id\"N\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id*o\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"$\n" +
+	"\x12RestoreTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"B\n" +
+	"\x0eAddTagsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
+	"\atag_ids\x18\x02 \x03(\x03R\x06tagIds\"E\n" +
+	"\x11RemoveTagsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
+	"\atag_ids\x18\x02 \x03(\x03R\x06tagIds\"g\n" +
+	"\x13ListSubtasksRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\x03R\bparentId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"d\n" +
+	"\x14ListSubtasksResponse\x12$\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"$\n" +
+	"\x12GetTaskTreeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"^\n" +
+	"\bTaskNode\x12\"\n" +
+	"\x04task\x18\x01 \x01(\v2\x0e.tasks.v1.TaskR\x04task\x12.\n" +
+	"\bchildren\x18\x02 \x03(\v2\x12.tasks.v1.TaskNodeR\bchildren\"S\n" +
+	"\x14AddDependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\"\n" +
+	"\rblocked_by_id\x18\x02 \x01(\x03R\vblockedById\"V\n" +
+	"\x17RemoveDependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\"\n" +
+	"\rblocked_by_id\x18\x02 \x01(\x03R\vblockedById\"2\n" +
+	"\x17ListDependenciesRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"u\n" +
+	"\x18ListDependenciesResponse\x12-\n" +
+	"\n" +
+	"blocked_by\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\tblockedBy\x12*\n" +
+	"\bblocking\x18\x02 \x03(\v2\x0e.tasks.v1.TaskR\bblocking\"]\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\xe3\x01\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.tasks.v1.TaskEventTypeR\x04type\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12/\n" +
+	"\achanges\x18\x05 \x03(\v2\x15.tasks.v1.FieldChangeR\achanges\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"m\n" +
+	"\x16ListTaskHistoryRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
+	"\x17ListTaskHistoryResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.tasks.v1.TaskEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"u\n" +
+	"\x17BatchCreateTasksRequest\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.tasks.v1.CreateTaskRequestR\x05items\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.tasks.v1.BatchModeR\x04mode\"u\n" +
+	"\x17BatchUpdateTasksRequest\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.tasks.v1.UpdateTaskRequestR\x05items\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.tasks.v1.BatchModeR\x04mode\"T\n" +
+	"\x17BatchDeleteTasksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.tasks.v1.BatchModeR\x04mode\"\xe9\x04\n" +
+	"\x17BulkUpdateStatusRequest\x127\n" +
+	"\vfrom_status\x18\x01 \x01(\x0e2\x14.tasks.v1.TaskStatusH\x00R\n" +
+	"fromStatus\x12!\n" +
+	"\vcategory_id\x18\x02 \x01(\x03H\x01R\n" +
+	"categoryId\x12+\n" +
+	"\x10without_category\x18\x03 \x01(\bH\x01R\x0fwithoutCategory\x123\n" +
+	"\x15include_subcategories\x18\x04 \x01(\bR\x14includeSubcategories\x124\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x16.tasks.v1.TaskPriorityH\x02R\bpriority\x129\n" +
+	"\n" +
+	"due_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdueBefore\x127\n" +
+	"\tdue_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bdueAfter\x12\x18\n" +
+	"\aoverdue\x18\b \x01(\bR\aoverdue\x12\x1e\n" +
+	"\vany_tag_ids\x18\t \x03(\x03R\tanyTagIds\x12\x1e\n" +
+	"\vall_tag_ids\x18\n" +
+	" \x03(\x03R\tallTagIds\x12,\n" +
+	"\x06status\x18\v \x01(\x0e2\x14.tasks.v1.TaskStatusR\x06status\x12'\n" +
+	"\x04mode\x18\f \x01(\x0e2\x13.tasks.v1.BatchModeR\x04modeB\x0f\n" +
+	"\rstatus_filterB\x11\n" +
+	"\x0fcategory_filterB\x11\n" +
+	"\x0fpriority_filter\"x\n" +
+	"\x0fBatchItemResult\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\"\n" +
+	"\x04task\x18\x04 \x01(\v2\x0e.tasks.v1.TaskR\x04task\"\x7f\n" +
+	"\x12BatchTasksResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.tasks.v1.BatchItemResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\xad\x01\n" +
+	"\x11WatchTasksRequest\x12$\n" +
+	"\vcategory_id\x18\x01 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.tasks.v1.TaskStatusH\x01R\x06status\x88\x01\x01\x12$\n" +
+	"\x0eafter_event_id\x18\x03 \x01(\x03R\fafterEventIdB\x0e\n" +
+	"\f_category_idB\t\n" +
+	"\a_status\"[\n" +
+	"\n" +
+	"TaskChange\x12)\n" +
+	"\x05event\x18\x01 \x01(\v2\x13.tasks.v1.TaskEventR\x05event\x12\"\n" +
+	"\x04task\x18\x02 \x01(\v2\x0e.tasks.v1.TaskR\x04task*o\n" +
 	"\n" +
 	"TaskStatus\x12\x14\n" +
 	"\x10TASK_STATUS_TODO\x10\x00\x12\x1b\n" +
 	"\x17TASK_STATUS_IN_PROGRESS\x10\x01\x12\x14\n" +
 	"\x10TASK_STATUS_DONE\x10\x02\x12\x18\n" +
-	"\x14TASK_STATUS_ARCHIVED\x10\x032\xf9\x02\n" +
+	"\x14TASK_STATUS_ARCHIVED\x10\x03*q\n" +
+	"\fTaskPriority\x12\x15\n" +
+	"\x11TASK_PRIORITY_LOW\x10\x00\x12\x18\n" +
+	"\x14TASK_PRIORITY_MEDIUM\x10\x01\x12\x16\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x02\x12\x18\n" +
+	"\x14TASK_PRIORITY_URGENT\x10\x03*_\n" +
+	"\bTaskSort\x12\x1d\n" +
+	"\x19TASK_SORT_CREATED_AT_DESC\x10\x00\x12\x1b\n" +
+	"\x17TASK_SORT_PRIORITY_DESC\x10\x01\x12\x17\n" +
+	"\x13TASK_SORT_RELEVANCE\x10\x02*\x87\x01\n" +
+	"\rTaskEventType\x12\x16\n" +
+	"\x12TASK_EVENT_CREATED\x10\x00\x12\x16\n" +
+	"\x12TASK_EVENT_UPDATED\x10\x01\x12\x16\n" +
+	"\x12TASK_EVENT_DELETED\x10\x02\x12\x17\n" +
+	"\x13TASK_EVENT_RESTORED\x10\x03\x12\x15\n" +
+	"\x11TASK_EVENT_PURGED\x10\x04*F\n" +
+	"\tBatchMode\x12\x1d\n" +
+	"\x19BATCH_MODE_ALL_OR_NOTHING\x10\x00\x12\x1a\n" +
+	"\x16BATCH_MODE_BEST_EFFORT\x10\x012\x92\f\n" +
 	"\fTasksService\x129\n" +
 	"\n" +
 	"CreateTask\x12\x1b.tasks.v1.CreateTaskRequest\x1a\x0e.tasks.v1.Task\x123\n" +
@@ -640,7 +2991,26 @@ const file_proto_tasks_tasks_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTask\x12\x1b.tasks.v1.UpdateTaskRequest\x1a\x0e.tasks.v1.Task\x12A\n" +
 	"\n" +
-	"DeleteTask\x12\x1b.tasks.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x128\n" +
+	"DeleteTask\x12\x1b.tasks.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\vRestoreTask\x12\x1c.tasks.v1.RestoreTaskRequest\x1a\x0e.tasks.v1.Task\x129\n" +
+	"\n" +
+	"AssignTask\x12\x1b.tasks.v1.AssignTaskRequest\x1a\x0e.tasks.v1.Task\x12=\n" +
+	"\fUnassignTask\x12\x1d.tasks.v1.UnassignTaskRequest\x1a\x0e.tasks.v1.Task\x123\n" +
+	"\aAddTags\x12\x18.tasks.v1.AddTagsRequest\x1a\x0e.tasks.v1.Task\x129\n" +
+	"\n" +
+	"RemoveTags\x12\x1b.tasks.v1.RemoveTagsRequest\x1a\x0e.tasks.v1.Task\x12M\n" +
+	"\fListSubtasks\x12\x1d.tasks.v1.ListSubtasksRequest\x1a\x1e.tasks.v1.ListSubtasksResponse\x12?\n" +
+	"\vGetTaskTree\x12\x1c.tasks.v1.GetTaskTreeRequest\x1a\x12.tasks.v1.TaskNode\x12G\n" +
+	"\rAddDependency\x12\x1e.tasks.v1.AddDependencyRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x10RemoveDependency\x12!.tasks.v1.RemoveDependencyRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x10ListDependencies\x12!.tasks.v1.ListDependenciesRequest\x1a\".tasks.v1.ListDependenciesResponse\x12V\n" +
+	"\x0fListTaskHistory\x12 .tasks.v1.ListTaskHistoryRequest\x1a!.tasks.v1.ListTaskHistoryResponse\x12A\n" +
+	"\n" +
+	"WatchTasks\x12\x1b.tasks.v1.WatchTasksRequest\x1a\x14.tasks.v1.TaskChange0\x01\x12S\n" +
+	"\x10BatchCreateTasks\x12!.tasks.v1.BatchCreateTasksRequest\x1a\x1c.tasks.v1.BatchTasksResponse\x12S\n" +
+	"\x10BatchUpdateTasks\x12!.tasks.v1.BatchUpdateTasksRequest\x1a\x1c.tasks.v1.BatchTasksResponse\x12S\n" +
+	"\x10BatchDeleteTasks\x12!.tasks.v1.BatchDeleteTasksRequest\x1a\x1c.tasks.v1.BatchTasksResponse\x12S\n" +
+	"\x10BulkUpdateStatus\x12!.tasks.v1.BulkUpdateStatusRequest\x1a\x1c.tasks.v1.BatchTasksResponse\x128\n" +
 	"\x04Ping\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00B8Z6task-manager-microservice/services/proto/tasks;taskspbb\x06proto3"
 
 var (
@@ -655,46 +3025,149 @@ func file_proto_tasks_tasks_proto_rawDescGZIP() []byte {
 	return file_proto_tasks_tasks_proto_rawDescData
 }
 
-var file_proto_tasks_tasks_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_tasks_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_tasks_tasks_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_tasks_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_tasks_tasks_proto_goTypes = []any{
-	(TaskStatus)(0),               // 0: tasks.v1.TaskStatus
-	(*Task)(nil),                  // 1: tasks.v1.Task
-	(*CreateTaskRequest)(nil),     // 2: tasks.v1.CreateTaskRequest
-	(*GetTaskRequest)(nil),        // 3: tasks.v1.GetTaskRequest
-	(*ListTaskRequest)(nil),       // 4: tasks.v1.ListTaskRequest
-	(*ListTaskResponse)(nil),      // 5: tasks.v1.ListTaskResponse
-	(*UpdateTaskRequest)(nil),     // 6: tasks.v1.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),     // 7: tasks.v1.DeleteTaskRequest
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil), // 9: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+	(TaskStatus)(0),                  // 0: tasks.v1.TaskStatus
+	(TaskPriority)(0),                // 1: tasks.v1.TaskPriority
+	(TaskSort)(0),                    // 2: tasks.v1.TaskSort
+	(TaskEventType)(0),               // 3: tasks.v1.TaskEventType
+	(BatchMode)(0),                   // 4: tasks.v1.BatchMode
+	(*Task)(nil),                     // 5: tasks.v1.Task
+	(*CreateTaskRequest)(nil),        // 6: tasks.v1.CreateTaskRequest
+	(*AssignTaskRequest)(nil),        // 7: tasks.v1.AssignTaskRequest
+	(*UnassignTaskRequest)(nil),      // 8: tasks.v1.UnassignTaskRequest
+	(*GetTaskRequest)(nil),           // 9: tasks.v1.GetTaskRequest
+	(*ListTaskRequest)(nil),          // 10: tasks.v1.ListTaskRequest
+	(*ListTaskResponse)(nil),         // 11: tasks.v1.ListTaskResponse
+	(*UpdateTaskRequest)(nil),        // 12: tasks.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),        // 13: tasks.v1.DeleteTaskRequest
+	(*RestoreTaskRequest)(nil),       // 14: tasks.v1.RestoreTaskRequest
+	(*AddTagsRequest)(nil),           // 15: tasks.v1.AddTagsRequest
+	(*RemoveTagsRequest)(nil),        // 16: tasks.v1.RemoveTagsRequest
+	(*ListSubtasksRequest)(nil),      // 17: tasks.v1.ListSubtasksRequest
+	(*ListSubtasksResponse)(nil),     // 18: tasks.v1.ListSubtasksResponse
+	(*GetTaskTreeRequest)(nil),       // 19: tasks.v1.GetTaskTreeRequest
+	(*TaskNode)(nil),                 // 20: tasks.v1.TaskNode
+	(*AddDependencyRequest)(nil),     // 21: tasks.v1.AddDependencyRequest
+	(*RemoveDependencyRequest)(nil),  // 22: tasks.v1.RemoveDependencyRequest
+	(*ListDependenciesRequest)(nil),  // 23: tasks.v1.ListDependenciesRequest
+	(*ListDependenciesResponse)(nil), // 24: tasks.v1.ListDependenciesResponse
+	(*FieldChange)(nil),              // 25: tasks.v1.FieldChange
+	(*TaskEvent)(nil),                // 26: tasks.v1.TaskEvent
+	(*ListTaskHistoryRequest)(nil),   // 27: tasks.v1.ListTaskHistoryRequest
+	(*ListTaskHistoryResponse)(nil),  // 28: tasks.v1.ListTaskHistoryResponse
+	(*BatchCreateTasksRequest)(nil),  // 29: tasks.v1.BatchCreateTasksRequest
+	(*BatchUpdateTasksRequest)(nil),  // 30: tasks.v1.BatchUpdateTasksRequest
+	(*BatchDeleteTasksRequest)(nil),  // 31: tasks.v1.BatchDeleteTasksRequest
+	(*BulkUpdateStatusRequest)(nil),  // 32: tasks.v1.BulkUpdateStatusRequest
+	(*BatchItemResult)(nil),          // 33: tasks.v1.BatchItemResult
+	(*BatchTasksResponse)(nil),       // 34: tasks.v1.BatchTasksResponse
+	(*WatchTasksRequest)(nil),        // 35: tasks.v1.WatchTasksRequest
+	(*TaskChange)(nil),               // 36: tasks.v1.TaskChange
+	nil,                              // 37: tasks.v1.ListTaskResponse.HighlightsEntry
+	(*timestamppb.Timestamp)(nil),    // 38: google.protobuf.Timestamp
+	(*Tag)(nil),                      // 39: tasks.v1.Tag
+	(*fieldmaskpb.FieldMask)(nil),    // 40: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),            // 41: google.protobuf.Empty
 }
 var file_proto_tasks_tasks_proto_depIdxs = []int32{
 	0,  // 0: tasks.v1.Task.status:type_name -> tasks.v1.TaskStatus
-	8,  // 1: tasks.v1.Task.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 2: tasks.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: tasks.v1.ListTaskRequest.status:type_name -> tasks.v1.TaskStatus
-	1,  // 4: tasks.v1.ListTaskResponse.tasks:type_name -> tasks.v1.Task
-	0,  // 5: tasks.v1.UpdateTaskRequest.status:type_name -> tasks.v1.TaskStatus
-	9,  // 6: tasks.v1.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
-	2,  // 7: tasks.v1.TasksService.CreateTask:input_type -> tasks.v1.CreateTaskRequest
-	3,  // 8: tasks.v1.TasksService.GetTask:input_type -> tasks.v1.GetTaskRequest
-	4,  // 9: tasks.v1.TasksService.ListTask:input_type -> tasks.v1.ListTaskRequest
-	6,  // 10: tasks.v1.TasksService.UpdateTask:input_type -> tasks.v1.UpdateTaskRequest
-	7,  // 11: tasks.v1.TasksService.DeleteTask:input_type -> tasks.v1.DeleteTaskRequest
-	10, // 12: tasks.v1.TasksService.Ping:input_type -> google.protobuf.Empty
-	1,  // 13: tasks.v1.TasksService.CreateTask:output_type -> tasks.v1.Task
-	1,  // 14: tasks.v1.TasksService.GetTask:output_type -> tasks.v1.Task
-	5,  // 15: tasks.v1.TasksService.ListTask:output_type -> tasks.v1.ListTaskResponse
-	1,  // 16: tasks.v1.TasksService.UpdateTask:output_type -> tasks.v1.Task
-	10, // 17: tasks.v1.TasksService.DeleteTask:output_type -> google.protobuf.Empty
-	10, // 18: tasks.v1.TasksService.Ping:output_type -> google.protobuf.Empty
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	38, // 1: tasks.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	38, // 2: tasks.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 3: tasks.v1.Task.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: tasks.v1.Task.priority:type_name -> tasks.v1.TaskPriority
+	39, // 5: tasks.v1.Task.tags:type_name -> tasks.v1.Tag
+	38, // 6: tasks.v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	38, // 7: tasks.v1.Task.archived_at:type_name -> google.protobuf.Timestamp
+	38, // 8: tasks.v1.Task.deleted_at:type_name -> google.protobuf.Timestamp
+	38, // 9: tasks.v1.CreateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 10: tasks.v1.CreateTaskRequest.priority:type_name -> tasks.v1.TaskPriority
+	0,  // 11: tasks.v1.ListTaskRequest.status:type_name -> tasks.v1.TaskStatus
+	38, // 12: tasks.v1.ListTaskRequest.due_before:type_name -> google.protobuf.Timestamp
+	38, // 13: tasks.v1.ListTaskRequest.due_after:type_name -> google.protobuf.Timestamp
+	1,  // 14: tasks.v1.ListTaskRequest.priority:type_name -> tasks.v1.TaskPriority
+	2,  // 15: tasks.v1.ListTaskRequest.sort:type_name -> tasks.v1.TaskSort
+	5,  // 16: tasks.v1.ListTaskResponse.tasks:type_name -> tasks.v1.Task
+	37, // 17: tasks.v1.ListTaskResponse.highlights:type_name -> tasks.v1.ListTaskResponse.HighlightsEntry
+	0,  // 18: tasks.v1.UpdateTaskRequest.status:type_name -> tasks.v1.TaskStatus
+	40, // 19: tasks.v1.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	38, // 20: tasks.v1.UpdateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	1,  // 21: tasks.v1.UpdateTaskRequest.priority:type_name -> tasks.v1.TaskPriority
+	5,  // 22: tasks.v1.ListSubtasksResponse.tasks:type_name -> tasks.v1.Task
+	5,  // 23: tasks.v1.TaskNode.task:type_name -> tasks.v1.Task
+	20, // 24: tasks.v1.TaskNode.children:type_name -> tasks.v1.TaskNode
+	5,  // 25: tasks.v1.ListDependenciesResponse.blocked_by:type_name -> tasks.v1.Task
+	5,  // 26: tasks.v1.ListDependenciesResponse.blocking:type_name -> tasks.v1.Task
+	3,  // 27: tasks.v1.TaskEvent.type:type_name -> tasks.v1.TaskEventType
+	25, // 28: tasks.v1.TaskEvent.changes:type_name -> tasks.v1.FieldChange
+	38, // 29: tasks.v1.TaskEvent.created_at:type_name -> google.protobuf.Timestamp
+	26, // 30: tasks.v1.ListTaskHistoryResponse.events:type_name -> tasks.v1.TaskEvent
+	6,  // 31: tasks.v1.BatchCreateTasksRequest.items:type_name -> tasks.v1.CreateTaskRequest
+	4,  // 32: tasks.v1.BatchCreateTasksRequest.mode:type_name -> tasks.v1.BatchMode
+	12, // 33: tasks.v1.BatchUpdateTasksRequest.items:type_name -> tasks.v1.UpdateTaskRequest
+	4,  // 34: tasks.v1.BatchUpdateTasksRequest.mode:type_name -> tasks.v1.BatchMode
+	4,  // 35: tasks.v1.BatchDeleteTasksRequest.mode:type_name -> tasks.v1.BatchMode
+	0,  // 36: tasks.v1.BulkUpdateStatusRequest.from_status:type_name -> tasks.v1.TaskStatus
+	1,  // 37: tasks.v1.BulkUpdateStatusRequest.priority:type_name -> tasks.v1.TaskPriority
+	38, // 38: tasks.v1.BulkUpdateStatusRequest.due_before:type_name -> google.protobuf.Timestamp
+	38, // 39: tasks.v1.BulkUpdateStatusRequest.due_after:type_name -> google.protobuf.Timestamp
+	0,  // 40: tasks.v1.BulkUpdateStatusRequest.status:type_name -> tasks.v1.TaskStatus
+	4,  // 41: tasks.v1.BulkUpdateStatusRequest.mode:type_name -> tasks.v1.BatchMode
+	5,  // 42: tasks.v1.BatchItemResult.task:type_name -> tasks.v1.Task
+	33, // 43: tasks.v1.BatchTasksResponse.results:type_name -> tasks.v1.BatchItemResult
+	0,  // 44: tasks.v1.WatchTasksRequest.status:type_name -> tasks.v1.TaskStatus
+	26, // 45: tasks.v1.TaskChange.event:type_name -> tasks.v1.TaskEvent
+	5,  // 46: tasks.v1.TaskChange.task:type_name -> tasks.v1.Task
+	6,  // 47: tasks.v1.TasksService.CreateTask:input_type -> tasks.v1.CreateTaskRequest
+	9,  // 48: tasks.v1.TasksService.GetTask:input_type -> tasks.v1.GetTaskRequest
+	10, // 49: tasks.v1.TasksService.ListTask:input_type -> tasks.v1.ListTaskRequest
+	12, // 50: tasks.v1.TasksService.UpdateTask:input_type -> tasks.v1.UpdateTaskRequest
+	13, // 51: tasks.v1.TasksService.DeleteTask:input_type -> tasks.v1.DeleteTaskRequest
+	14, // 52: tasks.v1.TasksService.RestoreTask:input_type -> tasks.v1.RestoreTaskRequest
+	7,  // 53: tasks.v1.TasksService.AssignTask:input_type -> tasks.v1.AssignTaskRequest
+	8,  // 54: tasks.v1.TasksService.UnassignTask:input_type -> tasks.v1.UnassignTaskRequest
+	15, // 55: tasks.v1.TasksService.AddTags:input_type -> tasks.v1.AddTagsRequest
+	16, // 56: tasks.v1.TasksService.RemoveTags:input_type -> tasks.v1.RemoveTagsRequest
+	17, // 57: tasks.v1.TasksService.ListSubtasks:input_type -> tasks.v1.ListSubtasksRequest
+	19, // 58: tasks.v1.TasksService.GetTaskTree:input_type -> tasks.v1.GetTaskTreeRequest
+	21, // 59: tasks.v1.TasksService.AddDependency:input_type -> tasks.v1.AddDependencyRequest
+	22, // 60: tasks.v1.TasksService.RemoveDependency:input_type -> tasks.v1.RemoveDependencyRequest
+	23, // 61: tasks.v1.TasksService.ListDependencies:input_type -> tasks.v1.ListDependenciesRequest
+	27, // 62: tasks.v1.TasksService.ListTaskHistory:input_type -> tasks.v1.ListTaskHistoryRequest
+	35, // 63: tasks.v1.TasksService.WatchTasks:input_type -> tasks.v1.WatchTasksRequest
+	29, // 64: tasks.v1.TasksService.BatchCreateTasks:input_type -> tasks.v1.BatchCreateTasksRequest
+	30, // 65: tasks.v1.TasksService.BatchUpdateTasks:input_type -> tasks.v1.BatchUpdateTasksRequest
+	31, // 66: tasks.v1.TasksService.BatchDeleteTasks:input_type -> tasks.v1.BatchDeleteTasksRequest
+	32, // 67: tasks.v1.TasksService.BulkUpdateStatus:input_type -> tasks.v1.BulkUpdateStatusRequest
+	41, // 68: tasks.v1.TasksService.Ping:input_type -> google.protobuf.Empty
+	5,  // 69: tasks.v1.TasksService.CreateTask:output_type -> tasks.v1.Task
+	5,  // 70: tasks.v1.TasksService.GetTask:output_type -> tasks.v1.Task
+	11, // 71: tasks.v1.TasksService.ListTask:output_type -> tasks.v1.ListTaskResponse
+	5,  // 72: tasks.v1.TasksService.UpdateTask:output_type -> tasks.v1.Task
+	41, // 73: tasks.v1.TasksService.DeleteTask:output_type -> google.protobuf.Empty
+	5,  // 74: tasks.v1.TasksService.RestoreTask:output_type -> tasks.v1.Task
+	5,  // 75: tasks.v1.TasksService.AssignTask:output_type -> tasks.v1.Task
+	5,  // 76: tasks.v1.TasksService.UnassignTask:output_type -> tasks.v1.Task
+	5,  // 77: tasks.v1.TasksService.AddTags:output_type -> tasks.v1.Task
+	5,  // 78: tasks.v1.TasksService.RemoveTags:output_type -> tasks.v1.Task
+	18, // 79: tasks.v1.TasksService.ListSubtasks:output_type -> tasks.v1.ListSubtasksResponse
+	20, // 80: tasks.v1.TasksService.GetTaskTree:output_type -> tasks.v1.TaskNode
+	41, // 81: tasks.v1.TasksService.AddDependency:output_type -> google.protobuf.Empty
+	41, // 82: tasks.v1.TasksService.RemoveDependency:output_type -> google.protobuf.Empty
+	24, // 83: tasks.v1.TasksService.ListDependencies:output_type -> tasks.v1.ListDependenciesResponse
+	28, // 84: tasks.v1.TasksService.ListTaskHistory:output_type -> tasks.v1.ListTaskHistoryResponse
+	36, // 85: tasks.v1.TasksService.WatchTasks:output_type -> tasks.v1.TaskChange
+	34, // 86: tasks.v1.TasksService.BatchCreateTasks:output_type -> tasks.v1.BatchTasksResponse
+	34, // 87: tasks.v1.TasksService.BatchUpdateTasks:output_type -> tasks.v1.BatchTasksResponse
+	34, // 88: tasks.v1.TasksService.BatchDeleteTasks:output_type -> tasks.v1.BatchTasksResponse
+	34, // 89: tasks.v1.TasksService.BulkUpdateStatus:output_type -> tasks.v1.BatchTasksResponse
+	41, // 90: tasks.v1.TasksService.Ping:output_type -> google.protobuf.Empty
+	69, // [69:91] is the sub-list for method output_type
+	47, // [47:69] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_proto_tasks_tasks_proto_init() }
@@ -702,19 +3175,32 @@ func file_proto_tasks_tasks_proto_init() {
 	if File_proto_tasks_tasks_proto != nil {
 		return
 	}
-	file_proto_tasks_tasks_proto_msgTypes[3].OneofWrappers = []any{
+	file_proto_tasks_tags_proto_init()
+	file_proto_tasks_tasks_proto_msgTypes[5].OneofWrappers = []any{
 		(*ListTaskRequest_Status)(nil),
 		(*ListTaskRequest_CategoryId)(nil),
 		(*ListTaskRequest_WithoutCategory)(nil),
+		(*ListTaskRequest_Priority)(nil),
+		(*ListTaskRequest_AssigneeId)(nil),
+		(*ListTaskRequest_AssignedToMe)(nil),
+		(*ListTaskRequest_Unassigned)(nil),
+	}
+	file_proto_tasks_tasks_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_tasks_tasks_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_tasks_tasks_proto_msgTypes[27].OneofWrappers = []any{
+		(*BulkUpdateStatusRequest_FromStatus)(nil),
+		(*BulkUpdateStatusRequest_CategoryId)(nil),
+		(*BulkUpdateStatusRequest_WithoutCategory)(nil),
+		(*BulkUpdateStatusRequest_Priority)(nil),
 	}
-	file_proto_tasks_tasks_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_tasks_tasks_proto_msgTypes[30].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tasks_tasks_proto_rawDesc), len(file_proto_tasks_tasks_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      5,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
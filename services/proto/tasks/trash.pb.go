@@ -0,0 +1,386 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/tasks/trash.proto
+
+package taskspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TrashKind int32
+
+const (
+	TrashKind_TRASH_KIND_TASKS      TrashKind = 0
+	TrashKind_TRASH_KIND_CATEGORIES TrashKind = 1
+)
+
+// Enum value maps for TrashKind.
+var (
+	TrashKind_name = map[int32]string{
+		0: "TRASH_KIND_TASKS",
+		1: "TRASH_KIND_CATEGORIES",
+	}
+	TrashKind_value = map[string]int32{
+		"TRASH_KIND_TASKS":      0,
+		"TRASH_KIND_CATEGORIES": 1,
+	}
+)
+
+func (x TrashKind) Enum() *TrashKind {
+	p := new(TrashKind)
+	*p = x
+	return p
+}
+
+func (x TrashKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrashKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tasks_trash_proto_enumTypes[0].Descriptor()
+}
+
+func (TrashKind) Type() protoreflect.EnumType {
+	return &file_proto_tasks_trash_proto_enumTypes[0]
+}
+
+func (x TrashKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrashKind.Descriptor instead.
+func (TrashKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tasks_trash_proto_rawDescGZIP(), []int{0}
+}
+
+type ListDeletedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  TrashKind              `protobuf:"varint,1,opt,name=kind,proto3,enum=tasks.v1.TrashKind" json:"kind,omitempty"`
+	// page_size <= 0 => 50, максимум 200
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedRequest) Reset() {
+	*x = ListDeletedRequest{}
+	mi := &file_proto_tasks_trash_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedRequest) ProtoMessage() {}
+
+func (x *ListDeletedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_trash_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_trash_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDeletedRequest) GetKind() TrashKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TrashKind_TRASH_KIND_TASKS
+}
+
+func (x *ListDeletedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeletedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// заполнен один из списков, по kind запроса
+	Tasks      []*Task     `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Categories []*Category `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	// пустой => больше страниц нет
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedResponse) Reset() {
+	*x = ListDeletedResponse{}
+	mi := &file_proto_tasks_trash_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedResponse) ProtoMessage() {}
+
+func (x *ListDeletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_trash_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_trash_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDeletedResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListDeletedResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListDeletedResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PurgeDeletedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// не задан => вся корзина
+	DeletedBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=deleted_before,json=deletedBefore,proto3" json:"deleted_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedRequest) Reset() {
+	*x = PurgeDeletedRequest{}
+	mi := &file_proto_tasks_trash_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_trash_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_trash_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PurgeDeletedRequest) GetDeletedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedBefore
+	}
+	return nil
+}
+
+type PurgeDeletedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         int64                  `protobuf:"varint,1,opt,name=tasks,proto3" json:"tasks,omitempty"`
+	Categories    int64                  `protobuf:"varint,2,opt,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedResponse) Reset() {
+	*x = PurgeDeletedResponse{}
+	mi := &file_proto_tasks_trash_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tasks_trash_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tasks_trash_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PurgeDeletedResponse) GetTasks() int64 {
+	if x != nil {
+		return x.Tasks
+	}
+	return 0
+}
+
+func (x *PurgeDeletedResponse) GetCategories() int64 {
+	if x != nil {
+		return x.Categories
+	}
+	return 0
+}
+
+var File_proto_tasks_trash_proto protoreflect.FileDescriptor
+
+const file_proto_tasks_trash_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/tasks/trash.proto\x12\btasks.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cproto/tasks/categories.proto\x1a\x17proto/tasks/tasks.proto\"y\n" +
+	"\x12ListDeletedRequest\x12'\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x13.tasks.v1.TrashKindR\x04kind\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x97\x01\n" +
+	"\x13ListDeletedResponse\x12$\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0e.tasks.v1.TaskR\x05tasks\x122\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\v2\x12.tasks.v1.CategoryR\n" +
+	"categories\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"X\n" +
+	"\x13PurgeDeletedRequest\x12A\n" +
+	"\x0edeleted_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rdeletedBefore\"L\n" +
+	"\x14PurgeDeletedResponse\x12\x14\n" +
+	"\x05tasks\x18\x01 \x01(\x03R\x05tasks\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x01(\x03R\n" +
+	"categories*<\n" +
+	"\tTrashKind\x12\x14\n" +
+	"\x10TRASH_KIND_TASKS\x10\x00\x12\x19\n" +
+	"\x15TRASH_KIND_CATEGORIES\x10\x012\xa9\x01\n" +
+	"\fTrashService\x12J\n" +
+	"\vListDeleted\x12\x1c.tasks.v1.ListDeletedRequest\x1a\x1d.tasks.v1.ListDeletedResponse\x12M\n" +
+	"\fPurgeDeleted\x12\x1d.tasks.v1.PurgeDeletedRequest\x1a\x1e.tasks.v1.PurgeDeletedResponseB8Z6task-manager-microservice/services/proto/tasks;taskspbb\x06proto3"
+
+var (
+	file_proto_tasks_trash_proto_rawDescOnce sync.Once
+	file_proto_tasks_trash_proto_rawDescData []byte
+)
+
+func file_proto_tasks_trash_proto_rawDescGZIP() []byte {
+	file_proto_tasks_trash_proto_rawDescOnce.Do(func() {
+		file_proto_tasks_trash_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tasks_trash_proto_rawDesc), len(file_proto_tasks_trash_proto_rawDesc)))
+	})
+	return file_proto_tasks_trash_proto_rawDescData
+}
+
+var file_proto_tasks_trash_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_tasks_trash_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_tasks_trash_proto_goTypes = []any{
+	(TrashKind)(0),                // 0: tasks.v1.TrashKind
+	(*ListDeletedRequest)(nil),    // 1: tasks.v1.ListDeletedRequest
+	(*ListDeletedResponse)(nil),   // 2: tasks.v1.ListDeletedResponse
+	(*PurgeDeletedRequest)(nil),   // 3: tasks.v1.PurgeDeletedRequest
+	(*PurgeDeletedResponse)(nil),  // 4: tasks.v1.PurgeDeletedResponse
+	(*Task)(nil),                  // 5: tasks.v1.Task
+	(*Category)(nil),              // 6: tasks.v1.Category
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_proto_tasks_trash_proto_depIdxs = []int32{
+	0, // 0: tasks.v1.ListDeletedRequest.kind:type_name -> tasks.v1.TrashKind
+	5, // 1: tasks.v1.ListDeletedResponse.tasks:type_name -> tasks.v1.Task
+	6, // 2: tasks.v1.ListDeletedResponse.categories:type_name -> tasks.v1.Category
+	7, // 3: tasks.v1.PurgeDeletedRequest.deleted_before:type_name -> google.protobuf.Timestamp
+	1, // 4: tasks.v1.TrashService.ListDeleted:input_type -> tasks.v1.ListDeletedRequest
+	3, // 5: tasks.v1.TrashService.PurgeDeleted:input_type -> tasks.v1.PurgeDeletedRequest
+	2, // 6: tasks.v1.TrashService.ListDeleted:output_type -> tasks.v1.ListDeletedResponse
+	4, // 7: tasks.v1.TrashService.PurgeDeleted:output_type -> tasks.v1.PurgeDeletedResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_tasks_trash_proto_init() }
+func file_proto_tasks_trash_proto_init() {
+	if File_proto_tasks_trash_proto != nil {
+		return
+	}
+	file_proto_tasks_categories_proto_init()
+	file_proto_tasks_tasks_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tasks_trash_proto_rawDesc), len(file_proto_tasks_trash_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_tasks_trash_proto_goTypes,
+		DependencyIndexes: file_proto_tasks_trash_proto_depIdxs,
+		EnumInfos:         file_proto_tasks_trash_proto_enumTypes,
+		MessageInfos:      file_proto_tasks_trash_proto_msgTypes,
+	}.Build()
+	File_proto_tasks_trash_proto = out.File
+	file_proto_tasks_trash_proto_goTypes = nil
+	file_proto_tasks_trash_proto_depIdxs = nil
+}
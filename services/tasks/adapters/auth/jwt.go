@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// ErrInvalidToken - подпись, формат или claims токена не прошли проверку
+var ErrInvalidToken = errors.New("invalid token")
+
+// clockSkew - допустимое расхождение часов с выпустившим токен
+const clockSkew = 30 * time.Second
+
+// Verifier проверяет JWT с подписью HS256 (общий секрет) и/или RS256 (публичный ключ).
+// Алгоритм берётся из заголовка токена, но принимается только тот, для которого задан ключ
+type Verifier struct {
+	secret   []byte
+	key      *rsa.PublicKey
+	issuer   string // пусто => iss не проверяется
+	audience string // пусто => aud не проверяется
+	now      func() time.Time
+
+	// кому выдаётся core.Principal.System: sub из списка или scope с systemScope
+	systemSubjects map[string]bool
+	systemScope    string
+}
+
+type VerifierOption func(*Verifier)
+
+// WithSystemSubjects выдаёт права на всё токенам сервисов с sub из subjects
+func WithSystemSubjects(subjects ...string) VerifierOption {
+	return func(v *Verifier) {
+		for _, sub := range subjects {
+			if sub = strings.TrimSpace(sub); sub != "" {
+				v.systemSubjects[sub] = true
+			}
+		}
+	}
+}
+
+// WithSystemScope выдаёт права на всё токенам, в claim scope (строка через пробел)
+// которых есть scope; пусто => claim не смотрится
+func WithSystemScope(scope string) VerifierOption {
+	return func(v *Verifier) {
+		v.systemScope = strings.TrimSpace(scope)
+	}
+}
+
+// NewVerifier создаёт проверку JWT; publicKeyPEM - RSA-ключ в PEM (PKIX или PKCS#1).
+// Нужен хотя бы один из секрета и ключа
+func NewVerifier(secret string, publicKeyPEM []byte, issuer, audience string, opts ...VerifierOption) (*Verifier, error) {
+	if secret == "" && len(publicKeyPEM) == 0 {
+		return nil, errors.New("jwt: neither secret nor public key is set")
+	}
+
+	v := &Verifier{issuer: issuer, audience: audience, now: time.Now, systemSubjects: map[string]bool{}}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if secret != "" {
+		v.secret = []byte(secret)
+	}
+	if len(publicKeyPEM) > 0 {
+		key, err := parseRSAPublicKey(publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		v.key = key
+	}
+	return v, nil
+}
+
+// Verify проверяет подпись и срок токена и возвращает вызывающего.
+// sub из целого положительного числа считается id пользователя; что такой
+// пользователь есть, проверяет core.Service.AuthenticateToken
+func (v *Verifier) Verify(token string) (core.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return core.Principal{}, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return core.Principal{}, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return core.Principal{}, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := v.verifySignature(header.Alg, parts[0]+"."+parts[1], sig); err != nil {
+		return core.Principal{}, err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return core.Principal{}, err
+	}
+	if err := v.checkClaims(c); err != nil {
+		return core.Principal{}, err
+	}
+
+	p := core.Principal{Subject: c.Subject, Method: core.AuthJWT, System: v.system(c)}
+	if id, err := strconv.ParseInt(c.Subject, 10, 64); err == nil && id > 0 {
+		p.UserID = id
+	}
+	return p, nil
+}
+
+// claims - зарегистрированные claims, которые проверяет сервис
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	Scope     string   `json:"scope"`
+}
+
+// audience - aud бывает строкой или массивом строк
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (v *Verifier) verifySignature(alg, signed string, sig []byte) error {
+	switch {
+	case alg == "HS256" && v.secret != nil:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	case alg == "RS256" && v.key != nil:
+		sum := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(v.key, crypto.SHA256, sum[:], sig); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	default:
+		// в том числе "none" и алгоритм без настроенного ключа
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, alg)
+	}
+}
+
+func (v *Verifier) checkClaims(c claims) error {
+	now := v.now()
+
+	if c.Subject == "" {
+		return fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+	// токен без срока действия не принимается
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	if now.After(unixTime(*c.ExpiresAt).Add(clockSkew)) {
+		return fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if c.NotBefore != nil && now.Add(clockSkew).Before(unixTime(*c.NotBefore)) {
+		return fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return fmt.Errorf("%w: unexpected iss", ErrInvalidToken)
+	}
+	if v.audience != "" && !slices.Contains(c.Audience, v.audience) {
+		return fmt.Errorf("%w: unexpected aud", ErrInvalidToken)
+	}
+	return nil
+}
+
+func (v *Verifier) system(c claims) bool {
+	if v.systemSubjects[c.Subject] {
+		return true
+	}
+	return v.systemScope != "" && slices.Contains(strings.Fields(c.Scope), v.systemScope)
+}
+
+// Helpers
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	return nil
+}
+
+// unixTime - NumericDate из JWT, дробная часть секунд отбрасывается
+func unixTime(sec float64) time.Time {
+	return time.Unix(int64(sec), 0)
+}
+
+func parseRSAPublicKey(b []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("jwt public key: no PEM block")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt public key: %w", err)
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("jwt public key: not an RSA key")
+		}
+		return rsaKey, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt public key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("jwt public key: unexpected PEM block %q", block.Type)
+	}
+}
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+var testNow = time.Unix(1_800_000_000, 0)
+
+func segment(t *testing.T, v any) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hs256(secret []byte, signed string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func rs256(t *testing.T, key *rsa.PrivateKey, signed string) string {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// token собирает JWT с заголовком alg; sign получает "<header>.<payload>"
+func token(t *testing.T, alg string, c map[string]any, sign func(string) string) string {
+	t.Helper()
+
+	signed := segment(t, map[string]string{"alg": alg, "typ": "JWT"}) + "." + segment(t, c)
+	return signed + "." + sign(signed)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{"sub": "42", "exp": testNow.Add(time.Hour).Unix()}
+}
+
+func newTestVerifier(t *testing.T, secret string, pub []byte, issuer, aud string) *Verifier {
+	t.Helper()
+
+	v, err := NewVerifier(secret, pub, issuer, aud)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func rsaKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerifyHS256(t *testing.T) {
+	v := newTestVerifier(t, testSecret, nil, "", "")
+
+	p, err := v.Verify(token(t, "HS256", validClaims(), func(s string) string { return hs256([]byte(testSecret), s) }))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if p.Subject != "42" || p.UserID != 42 {
+		t.Fatalf("principal %+v", p)
+	}
+}
+
+func TestVerifyRS256(t *testing.T) {
+	key, pub := rsaKey(t)
+	v := newTestVerifier(t, "", pub, "", "")
+
+	if _, err := v.Verify(token(t, "RS256", validClaims(), func(s string) string { return rs256(t, key, s) })); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestVerifyRejectsAlgorithms(t *testing.T) {
+	key, pub := rsaKey(t)
+	hsWith := func(secret []byte) func(string) string {
+		return func(s string) string { return hs256(secret, s) }
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		pub    []byte
+		token  string
+	}{
+		{"none", testSecret, pub, token(t, "none", validClaims(), func(string) string { return "" })},
+		{"None", testSecret, pub, token(t, "None", validClaims(), func(string) string { return "" })},
+		{"empty alg", testSecret, pub, token(t, "", validClaims(), hsWith([]byte(testSecret)))},
+		// публичный ключ известен всем: HS256 с ним вместо секрета - подделка
+		{"HS256 keyed with the public key", "", pub, token(t, "HS256", validClaims(), hsWith(pub))},
+		{"HS256 signature under RS256", testSecret, pub, token(t, "RS256", validClaims(), hsWith([]byte(testSecret)))},
+		{"RS256 without a public key", testSecret, nil, token(t, "RS256", validClaims(), func(s string) string { return rs256(t, key, s) })},
+		{"HS512", testSecret, nil, token(t, "HS512", validClaims(), hsWith([]byte(testSecret)))},
+		{"wrong secret", testSecret, nil, token(t, "HS256", validClaims(), hsWith([]byte("other")))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t, tt.secret, tt.pub, "", "")
+			if _, err := v.Verify(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsTamperedPayload(t *testing.T) {
+	v := newTestVerifier(t, testSecret, nil, "", "")
+	tok := token(t, "HS256", validClaims(), func(s string) string { return hs256([]byte(testSecret), s) })
+
+	parts := strings.Split(tok, ".")
+	c := validClaims()
+	c["sub"] = "1"
+	parts[1] = segment(t, c)
+
+	if _, err := v.Verify(strings.Join(parts, ".")); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		claims   map[string]any
+		ok       bool
+	}{
+		{"valid", "", "", map[string]any{}, true},
+		{"missing sub", "", "", map[string]any{"sub": ""}, false},
+		{"missing exp", "", "", map[string]any{"exp": nil}, false},
+		{"expired", "", "", map[string]any{"exp": testNow.Add(-clockSkew - time.Second).Unix()}, false},
+		{"expired within skew", "", "", map[string]any{"exp": testNow.Add(-clockSkew + time.Second).Unix()}, true},
+		{"not valid yet", "", "", map[string]any{"nbf": testNow.Add(clockSkew + time.Second).Unix()}, false},
+		{"nbf within skew", "", "", map[string]any{"nbf": testNow.Add(clockSkew - time.Second).Unix()}, true},
+		{"iss matches", "issuer", "", map[string]any{"iss": "issuer"}, true},
+		{"iss mismatch", "issuer", "", map[string]any{"iss": "other"}, false},
+		{"iss missing", "issuer", "", map[string]any{}, false},
+		{"aud string", "", "tasks", map[string]any{"aud": "tasks"}, true},
+		{"aud array", "", "tasks", map[string]any{"aud": []string{"other", "tasks"}}, true},
+		{"aud mismatch", "", "tasks", map[string]any{"aud": []string{"other"}}, false},
+		{"aud missing", "", "tasks", map[string]any{}, false},
+		{"aud not checked", "", "", map[string]any{"aud": "other"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validClaims()
+			for k, val := range tt.claims {
+				if val == nil {
+					delete(c, k)
+					continue
+				}
+				c[k] = val
+			}
+
+			v := newTestVerifier(t, testSecret, nil, tt.issuer, tt.audience)
+			_, err := v.Verify(token(t, "HS256", c, func(s string) string { return hs256([]byte(testSecret), s) }))
+			if tt.ok && err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifyNonNumericSubject(t *testing.T) {
+	v := newTestVerifier(t, testSecret, nil, "", "")
+	c := validClaims()
+	c["sub"] = "service-a"
+
+	p, err := v.Verify(token(t, "HS256", c, func(s string) string { return hs256([]byte(testSecret), s) }))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if p.UserID != 0 {
+		t.Fatalf("UserID = %d for a non-numeric sub", p.UserID)
+	}
+}
+
+func TestNewVerifierRequiresKey(t *testing.T) {
+	if _, err := NewVerifier("", nil, "", ""); err == nil {
+		t.Fatal("NewVerifier accepted no secret and no key")
+	}
+	if _, err := NewVerifier("", []byte("not a pem"), "", ""); err == nil {
+		t.Fatal("NewVerifier accepted a malformed key")
+	}
+}
+
+func TestVerifySystem(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		system bool
+	}{
+		{"user", map[string]any{"sub": "42"}, false},
+		{"listed subject", map[string]any{"sub": "svc-reports"}, true},
+		{"unlisted subject", map[string]any{"sub": "svc-other"}, false},
+		{"system scope", map[string]any{"sub": "svc-other", "scope": "tasks:read tasks:system"}, true},
+		{"scope prefix only", map[string]any{"sub": "svc-other", "scope": "tasks:system-lite"}, false},
+	}
+	v := newTestVerifier(t, testSecret, nil, "", "")
+	WithSystemSubjects("svc-reports", " ")(v)
+	WithSystemScope("tasks:system")(v)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validClaims()
+			for k, val := range tt.claims {
+				c[k] = val
+			}
+			p, err := v.Verify(token(t, "HS256", c, func(s string) string { return hs256([]byte(testSecret), s) }))
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if p.System != tt.system {
+				t.Fatalf("System = %v, want %v", p.System, tt.system)
+			}
+		})
+	}
+}
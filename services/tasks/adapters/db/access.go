@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Access
+
+// readableCategoriesSQL - подзапрос с id категорий, видимых пользователю $n:
+// категории с его ролью и все их потомки, включая лежащие в корзине
+func readableCategoriesSQL(n int) string {
+	return fmt.Sprintf(`
+		WITH RECURSIVE readable(id, depth) AS (
+			SELECT category_id, 0 FROM category_members WHERE user_id = $%d
+			UNION ALL
+			SELECT c.id, r.depth + 1
+			FROM categories c
+			JOIN readable r ON c.parent_id = r.id
+			WHERE r.depth < %d
+		)
+		SELECT id FROM readable`, n, maxTreeDepth)
+}
+
+// readableTaskSQL - условие "задача видна пользователю $n": её категория видна,
+// а задачу без категории видят автор и исполнитель
+func readableTaskSQL(n int) string {
+	return fmt.Sprintf(`(category_id IN (%s) OR (category_id IS NULL AND (creator_id = $%d OR assignee_id = $%d)))`,
+		readableCategoriesSQL(n), n, n)
+}
+
+func (db *DB) CategoryRole(ctx context.Context, categoryID, userID int64) (core.Role, error) {
+	const q = `
+		WITH RECURSIVE up(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.parent_id, u.depth + 1
+			FROM categories c
+			JOIN up u ON c.id = u.parent_id
+			WHERE u.depth < $3
+		)
+		SELECT
+			EXISTS (SELECT 1 FROM up) AS found,
+			COALESCE((
+				SELECT max(m.role)
+				FROM up
+				JOIN category_members m ON m.category_id = up.id AND m.user_id = $2
+			), 0) AS role;
+	`
+
+	var row struct {
+		Found bool      `db:"found"`
+		Role  core.Role `db:"role"`
+	}
+	if err := db.q(ctx).GetContext(ctx, &row, q, categoryID, userID, maxTreeDepth); err != nil {
+		return 0, fmt.Errorf("get category role: %w", err)
+	}
+	if !row.Found {
+		return 0, core.ErrCategoryNotFound
+	}
+	return row.Role, nil
+}
+
+func (db *DB) ReadableCategoryIDs(ctx context.Context, userID int64) ([]int64, error) {
+	q := `SELECT DISTINCT id FROM (` + readableCategoriesSQL(1) + `) r`
+
+	var out []int64
+	if err := db.q(ctx).SelectContext(ctx, &out, q, userID); err != nil {
+		return nil, fmt.Errorf("list readable categories: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) SetCategoryMember(ctx context.Context, m core.CategoryMember) (core.CategoryMember, error) {
+	const q = `
+		INSERT INTO category_members(category_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (category_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		RETURNING category_id, user_id, role, created_at;
+	`
+
+	var out core.CategoryMember
+	if err := db.q(ctx).GetContext(ctx, &out, q, m.CategoryID, m.UserID, int16(m.Role)); err != nil {
+		return core.CategoryMember{}, fmt.Errorf("set category member: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) RemoveCategoryMember(ctx context.Context, categoryID, userID int64) error {
+	const q = `DELETE FROM category_members WHERE category_id = $1 AND user_id = $2`
+
+	res, err := db.q(ctx).ExecContext(ctx, q, categoryID, userID)
+	if err != nil {
+		return fmt.Errorf("remove category member: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("remove category member rows affected: %w", err)
+	}
+	if n == 0 {
+		return core.ErrCategoryMemberNotFound
+	}
+	return nil
+}
+
+func (db *DB) CountCategoryOwners(ctx context.Context, categoryID, exceptUserID int64) (int, error) {
+	const q = `
+		WITH RECURSIVE up(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.parent_id, u.depth + 1
+			FROM categories c
+			JOIN up u ON c.id = u.parent_id
+			WHERE u.depth < $4
+		)
+		SELECT count(DISTINCT m.user_id)
+		FROM up
+		JOIN category_members m ON m.category_id = up.id
+		WHERE m.role = $3 AND NOT (up.id = $1 AND m.user_id = $2);
+	`
+
+	var n int
+	if err := db.q(ctx).GetContext(ctx, &n, q, categoryID, exceptUserID, int16(core.RoleOwner), maxTreeDepth); err != nil {
+		return 0, fmt.Errorf("count category owners: %w", err)
+	}
+	return n, nil
+}
+
+func (db *DB) ListCategoryMembers(ctx context.Context, categoryID int64) ([]core.CategoryMember, error) {
+	const q = `
+		SELECT category_id, user_id, role, created_at
+		FROM category_members
+		WHERE category_id = $1
+		ORDER BY role DESC, user_id
+	`
+
+	var out []core.CategoryMember
+	if err := db.q(ctx).SelectContext(ctx, &out, q, categoryID); err != nil {
+		return nil, fmt.Errorf("list category members: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// API keys
+
+// apiKeyColumns - колонки api_keys в порядке полей core.APIKey
+const apiKeyColumns = `id, user_id, name, prefix, created_at, last_used_at, revoked_at`
+
+func (db *DB) CreateAPIKey(ctx context.Context, in core.NewAPIKey, prefix, hash string) (core.APIKey, error) {
+	const q = `
+		INSERT INTO api_keys(user_id, name, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + apiKeyColumns + `;
+	`
+
+	var k core.APIKey
+	if err := db.q(ctx).GetContext(ctx, &k, q, in.UserID, in.Name, prefix, hash); err != nil {
+		if isForeignKeyViolation(err) {
+			return core.APIKey{}, core.ErrUserNotFound
+		}
+		return core.APIKey{}, fmt.Errorf("insert api key: %w", err)
+	}
+	return k, nil
+}
+
+func (db *DB) ListAPIKeys(ctx context.Context, f core.ListAPIKeysFilter) ([]core.APIKey, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по id; без курсора - с начала списка
+	const q = `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE user_id = $1 AND ($2::bigint IS NULL OR id > $2)
+		ORDER BY id
+		LIMIT $3
+	`
+
+	var afterID *int64
+	if f.After != nil {
+		afterID = &f.After.ID
+	}
+
+	var out []core.APIKey
+	if err := db.q(ctx).SelectContext(ctx, &out, q, f.UserID, afterID, f.Limit); err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) GetAPIKey(ctx context.Context, id int64) (core.APIKey, error) {
+	const q = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	var k core.APIKey
+	if err := db.q(ctx).GetContext(ctx, &k, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.APIKey{}, core.ErrAPIKeyNotFound
+		}
+		return core.APIKey{}, fmt.Errorf("get api key: %w", err)
+	}
+	return k, nil
+}
+
+// RevokeAPIKey отзывает ключ; у отозванного остаётся время первого отзыва
+func (db *DB) RevokeAPIKey(ctx context.Context, id int64) error {
+	const q = `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, now()) WHERE id = $1`
+
+	res, err := db.q(ctx).ExecContext(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("revoke api key rows affected: %w", err)
+	}
+	if n == 0 {
+		return core.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIKey находит ключ по хешу. last_used_at обновляется не чаще
+// раза в минуту, чтобы каждый запрос не писал в api_keys
+func (db *DB) AuthenticateAPIKey(ctx context.Context, hash string) (core.APIKey, error) {
+	const q = `
+		WITH k AS (
+			SELECT ` + apiKeyColumns + `
+			FROM api_keys
+			WHERE key_hash = $1 AND revoked_at IS NULL
+		), touched AS (
+			UPDATE api_keys
+			SET last_used_at = now()
+			WHERE id IN (SELECT id FROM k)
+			  AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+		)
+		SELECT ` + apiKeyColumns + ` FROM k;
+	`
+
+	var k core.APIKey
+	if err := db.q(ctx).GetContext(ctx, &k, q, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.APIKey{}, core.ErrUnauthenticated
+		}
+		return core.APIKey{}, fmt.Errorf("authenticate api key: %w", err)
+	}
+	return k, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Batch
+
+// BatchCreateTasks создаёт задачи в одной транзакции
+func (db *DB) BatchCreateTasks(ctx context.Context, items []core.NewTask, atomic bool) ([]core.BatchResult, error) {
+	return db.runBatch(ctx, len(items), atomic, func(tx querier, i int) (core.BatchResult, error) {
+		t, err := createTaskTx(ctx, tx, items[i])
+		return core.BatchResult{TaskID: t.ID, Task: t}, err
+	})
+}
+
+// BatchUpdateTasks перезаписывает задачи в одной транзакции
+func (db *DB) BatchUpdateTasks(ctx context.Context, tasks []core.Task, atomic bool) ([]core.BatchResult, error) {
+	return db.runBatch(ctx, len(tasks), atomic, func(tx querier, i int) (core.BatchResult, error) {
+		t, err := updateTaskTx(ctx, tx, tasks[i])
+		return core.BatchResult{TaskID: tasks[i].ID, Task: t}, err
+	})
+}
+
+// BatchDeleteTasks переносит задачи в корзину в одной транзакции
+func (db *DB) BatchDeleteTasks(ctx context.Context, ids []int64, atomic bool) ([]core.BatchResult, error) {
+	return db.runBatch(ctx, len(ids), atomic, func(tx querier, i int) (core.BatchResult, error) {
+		res := core.BatchResult{TaskID: ids[i]}
+		old, err := lockTaskTx(ctx, tx, ids[i])
+		if err != nil {
+			return res, err
+		}
+		return res, deleteTaskTx(ctx, tx, old)
+	})
+}
+
+// runBatch выполняет fn для n элементов в одной транзакции.
+// Каждый элемент выполняется под savepoint: в режиме best effort ошибка
+// откатывает только его, в atomic режиме - весь пакет, а остальные
+// элементы получают core.ErrBatchAborted.
+// Ошибкой самого runBatch считаются только сбои транзакции
+func (db *DB) runBatch(ctx context.Context, n int, atomic bool, fn func(tx querier, i int) (core.BatchResult, error)) ([]core.BatchResult, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin batch: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// внутри WithTx Rollback ничего не делает, пакет откатываем до savepoint
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT batch`); err != nil {
+		return nil, fmt.Errorf("savepoint batch: %w", err)
+	}
+
+	out := make([]core.BatchResult, n)
+	for i := range n {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT batch_item`); err != nil {
+			return nil, fmt.Errorf("savepoint batch item: %w", err)
+		}
+
+		r, err := fn(tx, i)
+		if err == nil {
+			out[i] = r
+			continue
+		}
+		if !isItemErr(err) {
+			return nil, err
+		}
+
+		out[i] = core.BatchResult{TaskID: r.TaskID, Err: err}
+		if atomic {
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch`); err != nil {
+				return nil, fmt.Errorf("rollback batch: %w", err)
+			}
+			for j := range out {
+				if j != i {
+					out[j] = core.BatchResult{Err: core.ErrBatchAborted}
+				}
+			}
+			return out, nil
+		}
+		if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch_item`); err != nil {
+			return nil, fmt.Errorf("rollback batch item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit batch: %w", err)
+	}
+	return out, nil
+}
+
+// isItemErr - ошибка относится к элементу пакета, а не к соединению с БД
+func isItemErr(err error) bool {
+	return errors.Is(err, core.ErrTaskNotFound) ||
+		errors.Is(err, core.ErrTaskInvalidArgs) ||
+		errors.Is(err, core.ErrConflict) ||
+		errors.Is(err, core.ErrCategoryNotFound) ||
+		errors.Is(err, core.ErrUserNotFound) ||
+		errors.Is(err, core.ErrPermissionDenied)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Category merge
+
+// MergeCategories в одной транзакции переносит задачи и дочерние категории source
+// категорий в target, переносит source категории в корзину и записывает слияние
+func (db *DB) MergeCategories(ctx context.Context, targetID int64, sourceIDs []int64) (core.CategoryMerge, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("begin merge categories: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var target core.Category
+	if err := tx.GetContext(ctx, &target, `SELECT `+categoryColumns+` FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.CategoryMerge{}, core.ErrCategoryNotFound
+		}
+		return core.CategoryMerge{}, fmt.Errorf("get target category: %w", err)
+	}
+
+	var locked []int64
+	if err := tx.SelectContext(ctx, &locked, `SELECT id FROM categories WHERE id = ANY($1) AND deleted_at IS NULL FOR UPDATE`, sourceIDs); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("lock source categories: %w", err)
+	}
+	if len(locked) != len(sourceIDs) {
+		return core.CategoryMerge{}, core.ErrCategoryNotFound
+	}
+
+	// задачи в корзине тоже переезжают, чтобы после восстановления попасть в target
+	const moveQ = `
+		UPDATE tasks t
+		SET category_id = $1, updated_at = now(), version = t.version + 1
+		FROM categories c
+		WHERE c.id = t.category_id AND c.id = ANY($2)
+		RETURNING t.id, c.id AS category_id;
+	`
+	var moved []struct {
+		ID         int64 `db:"id"`
+		CategoryID int64 `db:"category_id"`
+	}
+	if err := tx.SelectContext(ctx, &moved, moveQ, targetID, sourceIDs); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("move category tasks: %w", err)
+	}
+	for _, m := range moved {
+		if err := insertTaskEvent(ctx, tx, m.ID, core.EventUpdated, fieldChange("category_id", m.CategoryID, targetID)); err != nil {
+			return core.CategoryMerge{}, err
+		}
+	}
+
+	const childrenQ = `UPDATE categories SET parent_id = $1 WHERE parent_id = ANY($2) AND NOT id = ANY($2)`
+	if _, err := tx.ExecContext(ctx, childrenQ, targetID, sourceIDs); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("move child categories: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET deleted_at = now() WHERE id = ANY($1)`, sourceIDs); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("delete source categories: %w", err)
+	}
+
+	m := core.CategoryMerge{
+		Target:     target,
+		SourceIDs:  sourceIDs,
+		MovedTasks: int64(len(moved)),
+		Actor:      core.ActorFromContext(ctx),
+	}
+
+	const recordQ = `
+		INSERT INTO category_merges(target_id, source_ids, moved_tasks, actor)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id, created_at;
+	`
+	if err := tx.QueryRowxContext(ctx, recordQ, targetID, sourceIDs, m.MovedTasks, m.Actor).Scan(&m.ID, &m.CreatedAt); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("record category merge: %w", err)
+	}
+
+	p := core.CategoryMergedPayload{MergeID: m.ID, TargetID: targetID, SourceIDs: sourceIDs, MovedTasks: m.MovedTasks}
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryMerged, targetID, p); err != nil {
+		return core.CategoryMerge{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.CategoryMerge{}, fmt.Errorf("commit merge categories: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Category tree
+
+// CategoryAncestors возвращает id всех предков категории, от родителя к корню
+func (db *DB) CategoryAncestors(ctx context.Context, id int64) ([]int64, error) {
+	const q = `
+		WITH RECURSIVE ancestors(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM categories WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, c.parent_id, a.depth + 1
+			FROM categories c
+			JOIN ancestors a ON c.id = a.parent_id
+			WHERE a.depth < $2
+		)
+		SELECT id FROM ancestors WHERE depth > 0 ORDER BY depth;
+	`
+
+	var out []int64
+	if err := db.q(ctx).SelectContext(ctx, &out, q, id, maxTreeDepth); err != nil {
+		return nil, fmt.Errorf("list category ancestors: %w", err)
+	}
+	return out, nil
+}
+
+// CategorySubtree возвращает категорию и всех её потомков, родители идут раньше детей.
+// rootID == 0 => все категории, начиная с корневых
+func (db *DB) CategorySubtree(ctx context.Context, rootID int64) ([]core.Category, error) {
+	const q = `
+		WITH RECURSIVE subtree(id, depth) AS (
+			SELECT id, 0 FROM categories
+			WHERE deleted_at IS NULL
+			  AND (($1::bigint = 0 AND parent_id IS NULL) OR id = $1)
+			UNION ALL
+			SELECT c.id, s.depth + 1
+			FROM categories c
+			JOIN subtree s ON c.parent_id = s.id
+			WHERE s.depth < $2 AND c.deleted_at IS NULL
+		)
+		SELECT ` + categoryColumns + `
+		FROM categories
+		JOIN subtree USING (id)
+		ORDER BY subtree.depth, position, lower(name), id;
+	`
+
+	var out []core.Category
+	if err := db.q(ctx).SelectContext(ctx, &out, q, rootID, maxTreeDepth); err != nil {
+		return nil, fmt.Errorf("get category subtree: %w", err)
+	}
+	if rootID != 0 && len(out) == 0 {
+		return nil, core.ErrCategoryNotFound
+	}
+	return out, nil
+}
+
+// MoveCategory меняет родителя категории, nil => корневая категория
+// categoryTreeLockKey - ключ advisory-блокировки дерева категорий
+const categoryTreeLockKey int64 = 0x636174747265 // "cattre"
+
+// LockCategoryTree берёт transaction-level advisory-блокировку: переносы категорий
+// с проверкой предков идут по одному. Вне транзакции блокировка сразу снимается
+func (db *DB) LockCategoryTree(ctx context.Context) error {
+	if _, err := db.q(ctx).ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, categoryTreeLockKey); err != nil {
+		return fmt.Errorf("lock category tree: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) MoveCategory(ctx context.Context, id int64, parentID *int64) (core.Category, error) {
+	const q = `
+		UPDATE categories
+		SET parent_id = $2
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + categoryColumns + `;
+	`
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Category{}, fmt.Errorf("begin move category: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var c core.Category
+	if err := tx.GetContext(ctx, &c, q, id, parentID); err != nil {
+		if isForeignKeyViolation(err) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
+		if isCheckViolation(err) {
+			return core.Category{}, core.ErrCategoryCycle
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
+		return core.Category{}, fmt.Errorf("move category: %w", err)
+	}
+
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryMoved, c.ID, core.NewCategoryPayload(c)); err != nil {
+		return core.Category{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Category{}, fmt.Errorf("commit move category: %w", err)
+	}
+	return c, nil
+}
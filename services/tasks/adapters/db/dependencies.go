@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Dependencies
+
+func (db *DB) AddDependency(ctx context.Context, taskID, blockedByID int64) error {
+	const q = `
+		INSERT INTO task_dependencies(task_id, blocked_by_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;
+	`
+
+	if _, err := db.q(ctx).ExecContext(ctx, q, taskID, blockedByID); err != nil {
+		if isForeignKeyViolation(err) {
+			return core.ErrTaskNotFound
+		}
+		if isCheckViolation(err) {
+			return core.ErrDependencyCycle
+		}
+		return fmt.Errorf("add dependency: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) RemoveDependency(ctx context.Context, taskID, blockedByID int64) error {
+	const q = `DELETE FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2`
+
+	res, err := db.q(ctx).ExecContext(ctx, q, taskID, blockedByID)
+	if err != nil {
+		return fmt.Errorf("remove dependency: %w", err)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return core.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// ListBlockers возвращает задачи, которые блокируют taskID
+func (db *DB) ListBlockers(ctx context.Context, taskID int64) ([]core.Task, error) {
+	const q = `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id IN (SELECT blocked_by_id FROM task_dependencies WHERE task_id = $1)
+		  AND deleted_at IS NULL
+		ORDER BY created_at, id;
+	`
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, q, taskID); err != nil {
+		return nil, fmt.Errorf("list blockers: %w", err)
+	}
+	return out, nil
+}
+
+// ListBlocked возвращает задачи, которые заблокированы taskID
+func (db *DB) ListBlocked(ctx context.Context, taskID int64) ([]core.Task, error) {
+	const q = `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id IN (SELECT task_id FROM task_dependencies WHERE blocked_by_id = $1)
+		  AND deleted_at IS NULL
+		ORDER BY created_at, id;
+	`
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, q, taskID); err != nil {
+		return nil, fmt.Errorf("list blocked: %w", err)
+	}
+	return out, nil
+}
+
+// CountOpenBlockers считает блокирующие задачи, которые ещё не done
+func (db *DB) CountOpenBlockers(ctx context.Context, taskID int64) (int, error) {
+	const q = `
+		SELECT count(*)
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocked_by_id
+		WHERE d.task_id = $1 AND t.deleted_at IS NULL AND t.status <> $2;
+	`
+
+	var n int
+	if err := db.q(ctx).GetContext(ctx, &n, q, taskID, int16(core.Done)); err != nil {
+		return 0, fmt.Errorf("count open blockers: %w", err)
+	}
+	return n, nil
+}
+
+// dependencyGraphLockKey - ключ advisory-блокировки графа зависимостей
+const dependencyGraphLockKey int64 = 0x646570677270 // "depgrp"
+
+// LockDependencyGraph берёт transaction-level advisory-блокировку: новые связи
+// с проверкой цикла добавляются по одной. Вне транзакции блокировка сразу снимается
+func (db *DB) LockDependencyGraph(ctx context.Context) error {
+	if _, err := db.q(ctx).ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, dependencyGraphLockKey); err != nil {
+		return fmt.Errorf("lock dependency graph: %w", err)
+	}
+	return nil
+}
+
+// DependencyPathExists проверяет, что from транзитивно заблокирована to.
+// Связи задач из корзины учитываются, чтобы восстановление не создало цикл
+func (db *DB) DependencyPathExists(ctx context.Context, from, to int64) (bool, error) {
+	const q = `
+		WITH RECURSIVE blockers(id) AS (
+			SELECT blocked_by_id FROM task_dependencies WHERE task_id = $1
+			UNION
+			SELECT d.blocked_by_id
+			FROM task_dependencies d
+			JOIN blockers b ON d.task_id = b.id
+		)
+		SELECT EXISTS (SELECT 1 FROM blockers WHERE id = $2);
+	`
+
+	var exists bool
+	if err := db.q(ctx).GetContext(ctx, &exists, q, from, to); err != nil {
+		return false, fmt.Errorf("check dependency path: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"task-manager-microservice/tasks/core"
+)
+
+// History
+
+// taskField - поле задачи, которое попадает в аудит
+type taskField struct {
+	name  string
+	value func(t core.Task) any
+}
+
+var taskFields = []taskField{
+	{name: "category_id", value: func(t core.Task) any { return t.CategoryID }},
+	{name: "parent_id", value: func(t core.Task) any { return t.ParentID }},
+	{name: "name", value: func(t core.Task) any { return t.Name }},
+	{name: "description", value: func(t core.Task) any { return t.Description }},
+	{name: "status", value: func(t core.Task) any { return t.Status }},
+	{name: "priority", value: func(t core.Task) any { return t.Priority }},
+	{name: "due_at", value: func(t core.Task) any { return t.DueAt }},
+	{name: "assignee_id", value: func(t core.Task) any { return t.AssigneeID }},
+}
+
+// taskChanges - отличающиеся поля old и new; nil old => создание, nil new => удаление
+func taskChanges(old, new *core.Task) []core.FieldChange {
+	var out []core.FieldChange
+	for _, f := range taskFields {
+		o, n := fieldJSON(old, f), fieldJSON(new, f)
+		if bytes.Equal(o, n) {
+			continue
+		}
+		out = append(out, core.FieldChange{Field: f.name, Old: o, New: n})
+	}
+	return out
+}
+
+func fieldJSON(t *core.Task, f taskField) json.RawMessage {
+	if t == nil {
+		return json.RawMessage("null")
+	}
+	return toJSON(f.value(*t))
+}
+
+// fieldChange - изменение одного поля, когда строки задачи целиком нет под рукой
+func fieldChange(field string, old, new any) []core.FieldChange {
+	return []core.FieldChange{{Field: field, Old: toJSON(old), New: toJSON(new)}}
+}
+
+func toJSON(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return b
+}
+
+// insertTaskEvent пишет событие аудита и доменное событие в outbox
+// в транзакции изменения, автор берётся из ctx
+func insertTaskEvent(ctx context.Context, tx querier, taskID int64, typ core.TaskEventType, changes []core.FieldChange) error {
+	if changes == nil {
+		changes = []core.FieldChange{}
+	}
+	b, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("marshal task changes: %w", err)
+	}
+
+	const q = `
+		INSERT INTO task_events(task_id, type, actor, changes)
+		VALUES ($1, $2, NULLIF($3, ''), $4::jsonb);
+	`
+	if _, err := tx.ExecContext(ctx, q, taskID, int16(typ), core.ActorFromContext(ctx), string(b)); err != nil {
+		return fmt.Errorf("insert task event: %w", err)
+	}
+	return insertTaskOutbox(ctx, tx, taskID, typ, changes)
+}
+
+func (db *DB) ListTaskEvents(ctx context.Context, f core.ListTaskEventsFilter) ([]core.TaskEvent, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по id DESC; без курсора - с самого нового события
+	const q = `
+		SELECT id, task_id, type, COALESCE(actor, '') AS actor, changes, created_at
+		FROM task_events
+		WHERE task_id = $1 AND ($2::bigint IS NULL OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	var afterID *int64
+	if f.After != nil {
+		afterID = &f.After.ID
+	}
+
+	return db.selectTaskEvents(ctx, q, f.TaskID, afterID, f.Limit)
+}
+
+// selectTaskEvents выполняет запрос по task_events и разбирает changes
+func (db *DB) selectTaskEvents(ctx context.Context, q string, args ...any) ([]core.TaskEvent, error) {
+	var rows []struct {
+		core.TaskEvent
+		Changes []byte `db:"changes"`
+	}
+	if err := db.q(ctx).SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, fmt.Errorf("list task events: %w", err)
+	}
+
+	out := make([]core.TaskEvent, 0, len(rows))
+	for _, r := range rows {
+		e := r.TaskEvent
+		if err := json.Unmarshal(r.Changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("unmarshal task changes: %w", err)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
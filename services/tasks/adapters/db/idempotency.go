@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Idempotency
+
+// ClaimIdempotencyKey занимает ключ k.Scope/k.Key. Если ключ уже занят и не
+// просрочен, возвращает его запись и found = true. Занятие того же ключа
+// параллельной транзакцией ждёт её завершения
+func (db *DB) ClaimIdempotencyKey(ctx context.Context, k core.IdempotencyKey) (core.IdempotencyKey, bool, error) {
+	q := db.q(ctx)
+
+	// просроченный ключ можно занять заново
+	const expiredQ = `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND expires_at <= now()`
+	if _, err := q.ExecContext(ctx, expiredQ, k.Scope, k.Key); err != nil {
+		return core.IdempotencyKey{}, false, fmt.Errorf("delete expired idempotency key: %w", err)
+	}
+
+	const claimQ = `
+		INSERT INTO idempotency_keys(scope, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (scope, key) DO NOTHING
+		RETURNING scope;
+	`
+	var scope string
+	err := q.GetContext(ctx, &scope, claimQ, k.Scope, k.Key, k.RequestHash, k.ExpiresAt)
+	if err == nil {
+		return k, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return core.IdempotencyKey{}, false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	const getQ = `
+		SELECT scope, key, request_hash, COALESCE(response, 'null') AS response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2;
+	`
+	var rec core.IdempotencyKey
+	if err := q.GetContext(ctx, &rec, getQ, k.Scope, k.Key); err != nil {
+		return core.IdempotencyKey{}, false, fmt.Errorf("get idempotency key: %w", err)
+	}
+	return rec, true, nil
+}
+
+// SaveIdempotencyResponse сохраняет ответ на запрос с занятым ключом
+func (db *DB) SaveIdempotencyResponse(ctx context.Context, scope, key string, response []byte) error {
+	const q = `UPDATE idempotency_keys SET response = $3 WHERE scope = $1 AND key = $2`
+	if _, err := db.q(ctx).ExecContext(ctx, q, scope, key, response); err != nil {
+		return fmt.Errorf("save idempotency response: %w", err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys удаляет ключи, просроченные к before
+func (db *DB) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	res, err := db.q(ctx).ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+	return res.RowsAffected()
+}
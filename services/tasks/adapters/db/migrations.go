@@ -11,16 +11,107 @@ var createCategoriesUp string
 //go:embed migrations/02_create_tasks.up.sql
 var createTasksUp string
 
+//go:embed migrations/03_add_tasks_due_at.up.sql
+var addTasksDueAtUp string
+
+//go:embed migrations/04_add_tasks_priority.up.sql
+var addTasksPriorityUp string
+
+//go:embed migrations/05_add_tasks_search.up.sql
+var addTasksSearchUp string
+
+//go:embed migrations/06_create_tags.up.sql
+var createTagsUp string
+
+//go:embed migrations/07_add_tasks_parent_id.up.sql
+var addTasksParentIDUp string
+
+//go:embed migrations/08_create_task_dependencies.up.sql
+var createTaskDependenciesUp string
+
+//go:embed migrations/09_add_tasks_status_timestamps.up.sql
+var addTasksStatusTimestampsUp string
+
+//go:embed migrations/10_create_task_events.up.sql
+var createTaskEventsUp string
+
+//go:embed migrations/11_add_soft_delete.up.sql
+var addSoftDeleteUp string
+
+//go:embed migrations/12_add_categories_parent_id.up.sql
+var addCategoriesParentIDUp string
+
+//go:embed migrations/13_add_categories_metadata.up.sql
+var addCategoriesMetadataUp string
+
+//go:embed migrations/14_create_category_merges.up.sql
+var createCategoryMergesUp string
+
+//go:embed migrations/15_add_tasks_version.up.sql
+var addTasksVersionUp string
+
+//go:embed migrations/16_create_idempotency_keys.up.sql
+var createIdempotencyKeysUp string
+
+//go:embed migrations/17_create_outbox.up.sql
+var createOutboxUp string
+
+//go:embed migrations/18_create_webhooks.up.sql
+var createWebhooksUp string
+
+//go:embed migrations/19_create_users.up.sql
+var createUsersUp string
+
+//go:embed migrations/20_create_api_keys.up.sql
+var createAPIKeysUp string
+
+//go:embed migrations/21_create_category_members.up.sql
+var createCategoryMembersUp string
+
+//go:embed migrations/22_add_task_events_seq.up.sql
+var addTaskEventsSeqUp string
+
+//go:embed migrations/23_add_outbox_aggregate_index.up.sql
+var addOutboxAggregateIndexUp string
+
+// migrations применяются по порядку, каждая миграция идемпотентна
+var migrations = []struct {
+	name string
+	sql  string
+}{
+	{name: "categories", sql: createCategoriesUp},
+	{name: "tasks", sql: createTasksUp},
+	{name: "tasks due_at", sql: addTasksDueAtUp},
+	{name: "tasks priority", sql: addTasksPriorityUp},
+	{name: "tasks search", sql: addTasksSearchUp},
+	{name: "tags", sql: createTagsUp},
+	{name: "tasks parent_id", sql: addTasksParentIDUp},
+	{name: "task dependencies", sql: createTaskDependenciesUp},
+	{name: "tasks status timestamps", sql: addTasksStatusTimestampsUp},
+	{name: "task events", sql: createTaskEventsUp},
+	{name: "soft delete", sql: addSoftDeleteUp},
+	{name: "categories parent_id", sql: addCategoriesParentIDUp},
+	{name: "categories metadata", sql: addCategoriesMetadataUp},
+	{name: "category merges", sql: createCategoryMergesUp},
+	{name: "tasks version", sql: addTasksVersionUp},
+	{name: "idempotency keys", sql: createIdempotencyKeysUp},
+	{name: "outbox", sql: createOutboxUp},
+	{name: "webhooks", sql: createWebhooksUp},
+	{name: "users", sql: createUsersUp},
+	{name: "api keys", sql: createAPIKeysUp},
+	{name: "category members", sql: createCategoryMembersUp},
+	{name: "task events seq", sql: addTaskEventsSeqUp},
+	{name: "outbox aggregate index", sql: addOutboxAggregateIndexUp},
+}
+
 // Migrate применяет миграции для task-сервиса
 func (db *DB) Migrate() error {
 	db.log.Debug("running tasksDB migrations")
 
-	if _, err := db.conn.Exec(createCategoriesUp); err != nil {
-		return fmt.Errorf("apply categories migration: %w", err)
-	}
-
-	if _, err := db.conn.Exec(createTasksUp); err != nil {
-		return fmt.Errorf("apply tasks migration: %w", err)
+	for _, m := range migrations {
+		if _, err := db.conn.Exec(m.sql); err != nil {
+			return fmt.Errorf("apply %s migration: %w", m.name, err)
+		}
 	}
 
 	db.log.Debug("tasksDB migrations finished")
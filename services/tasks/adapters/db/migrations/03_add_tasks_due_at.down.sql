@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_due_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS due_at timestamptz NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at
+    ON tasks (due_at)
+    WHERE due_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_priority_created_at;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_priority;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
\ No newline at end of file
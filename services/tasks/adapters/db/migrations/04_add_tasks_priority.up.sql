@@ -0,0 +1,20 @@
+-- 0 low, 1 medium, 2 high, 3 urgent
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'chk_tasks_priority'
+      AND conrelid = 'tasks'::regclass
+  ) THEN
+ALTER TABLE tasks
+    ADD CONSTRAINT chk_tasks_priority
+        CHECK (priority IN (0, 1, 2, 3));
+END IF;
+END$$;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_priority_created_at
+    ON tasks (priority DESC, created_at DESC);
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- name весит больше description при ранжировании
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS search tsvector
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_search
+    ON tasks USING GIN (search);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_task_tags_tag_id;
+DROP TABLE IF EXISTS task_tags;
+DROP INDEX IF EXISTS ux_tags_name;
+DROP TABLE IF EXISTS tags;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- подзадачи: при удалении родителя подзадачи становятся задачами верхнего уровня
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT NULL REFERENCES tasks(id) ON DELETE SET NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'chk_tasks_parent_not_self'
+      AND conrelid = 'tasks'::regclass
+  ) THEN
+ALTER TABLE tasks
+    ADD CONSTRAINT chk_tasks_parent_not_self
+        CHECK (parent_id <> id);
+END IF;
+END$$;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id
+    ON tasks (parent_id);
\ No newline at end of file
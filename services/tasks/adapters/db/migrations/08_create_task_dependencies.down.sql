@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_dependencies_blocked_by_id;
+DROP TABLE IF EXISTS task_dependencies;
\ No newline at end of file
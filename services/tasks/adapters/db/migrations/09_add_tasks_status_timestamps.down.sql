@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS completed_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS archived_at  timestamptz NULL;
+
+-- уже завершённые задачи: точного момента нет, берём updated_at
+UPDATE tasks SET completed_at = updated_at WHERE status = 2 AND completed_at IS NULL;
+UPDATE tasks SET archived_at = updated_at WHERE status = 3 AND archived_at IS NULL;
\ No newline at end of file
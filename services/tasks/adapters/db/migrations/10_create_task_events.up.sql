@@ -0,0 +1,13 @@
+-- аудит задач: 0 created, 1 updated, 2 deleted
+-- без FK на tasks, чтобы история переживала удаление задачи
+CREATE TABLE IF NOT EXISTS task_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    type smallint NOT NULL CHECK (type IN (0, 1, 2)),
+    actor text NULL,
+    changes jsonb NOT NULL DEFAULT '[]'::jsonb,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id_id
+    ON task_events (task_id, id DESC);
\ No newline at end of file
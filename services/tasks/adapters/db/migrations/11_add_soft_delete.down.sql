@@ -0,0 +1,17 @@
+ALTER TABLE task_events DROP CONSTRAINT IF EXISTS chk_task_events_type;
+DELETE FROM task_events WHERE type IN (3, 4);
+ALTER TABLE task_events ADD CONSTRAINT task_events_type_check CHECK (type IN (0, 1, 2));
+
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+-- задачи и категории из корзины удаляются окончательно
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS ux_categories_name_active;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_categories_name
+    ON categories (lower(name));
+
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
@@ -0,0 +1,36 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz NULL;
+
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz NULL;
+
+-- имя категории уникально только среди категорий вне корзины
+DROP INDEX IF EXISTS ux_categories_name;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_categories_name_active
+    ON categories (lower(name))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at
+    ON tasks (deleted_at DESC, id DESC)
+    WHERE deleted_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at
+    ON categories (deleted_at DESC, id DESC)
+    WHERE deleted_at IS NOT NULL;
+
+-- события: 3 restored, 4 purged
+ALTER TABLE task_events DROP CONSTRAINT IF EXISTS task_events_type_check;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'chk_task_events_type'
+      AND conrelid = 'task_events'::regclass
+  ) THEN
+ALTER TABLE task_events
+    ADD CONSTRAINT chk_task_events_type
+        CHECK (type IN (0, 1, 2, 3, 4));
+END IF;
+END$$;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- вложенные категории: при окончательном удалении родителя категория становится корневой
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT NULL REFERENCES categories(id) ON DELETE SET NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'chk_categories_parent_not_self'
+      AND conrelid = 'categories'::regclass
+  ) THEN
+ALTER TABLE categories
+    ADD CONSTRAINT chk_categories_parent_not_self
+        CHECK (parent_id <> id);
+END IF;
+END$$;
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id
+    ON categories (parent_id);
\ No newline at end of file
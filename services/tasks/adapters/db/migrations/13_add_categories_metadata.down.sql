@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_categories_position_name;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_color;
+ALTER TABLE categories DROP COLUMN IF EXISTS position;
+ALTER TABLE categories DROP COLUMN IF EXISTS icon;
+ALTER TABLE categories DROP COLUMN IF EXISTS color;
+ALTER TABLE categories DROP COLUMN IF EXISTS description;
\ No newline at end of file
@@ -0,0 +1,24 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS description text NULL,
+    ADD COLUMN IF NOT EXISTS color text NULL,
+    ADD COLUMN IF NOT EXISTS icon text NULL,
+    ADD COLUMN IF NOT EXISTS position integer NOT NULL DEFAULT 0;
+
+-- цвет в формате #rrggbb
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM pg_constraint
+    WHERE conname = 'chk_categories_color'
+      AND conrelid = 'categories'::regclass
+  ) THEN
+ALTER TABLE categories
+    ADD CONSTRAINT chk_categories_color
+        CHECK (color ~ '^#[0-9a-f]{6}$');
+END IF;
+END$$;
+
+CREATE INDEX IF NOT EXISTS idx_categories_position_name
+    ON categories (position, lower(name), id)
+    WHERE deleted_at IS NULL;
\ No newline at end of file
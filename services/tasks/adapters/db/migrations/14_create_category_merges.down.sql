@@ -0,0 +1 @@
+DROP TABLE IF EXISTS category_merges;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- журнал слияний категорий; source категории к этому моменту уже в корзине
+CREATE TABLE IF NOT EXISTS category_merges (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    target_id BIGINT NOT NULL,
+    source_ids BIGINT[] NOT NULL,
+    moved_tasks BIGINT NOT NULL DEFAULT 0,
+    actor text NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_merges_target_id
+    ON category_merges (target_id);
\ No newline at end of file
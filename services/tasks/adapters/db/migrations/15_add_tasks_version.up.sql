@@ -0,0 +1,2 @@
+-- версия задачи для оптимистичных блокировок, растёт при каждой записи
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
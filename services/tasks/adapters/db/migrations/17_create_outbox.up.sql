@@ -0,0 +1,22 @@
+-- transactional outbox: доменные события пишутся в транзакции изменения,
+-- relay публикует их в брокер не меньше одного раза
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    type text NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    payload jsonb NOT NULL,
+    actor text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    published_at timestamptz NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    last_error text NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending
+    ON outbox (next_attempt_at, id)
+    WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at
+    ON outbox (published_at)
+    WHERE published_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    url text NOT NULL,
+    secret text NOT NULL,
+    -- пустой массив => все типы событий
+    event_types text[] NOT NULL DEFAULT '{}',
+    category_id BIGINT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- доставка одного события outbox одному вебхуку;
+-- status: 0 - ждёт отправки, 1 - доставлено, 2 - попытки исчерпаны
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,
+    event_type text NOT NULL,
+    payload jsonb NOT NULL,
+    status smallint NOT NULL DEFAULT 0,
+    attempts integer NOT NULL DEFAULT 0,
+    response_code integer NULL,
+    last_error text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    delivered_at timestamptz NULL,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON webhook_deliveries (next_attempt_at, id)
+    WHERE status = 0;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id
+    ON webhook_deliveries (webhook_id, id DESC);
\ No newline at end of file
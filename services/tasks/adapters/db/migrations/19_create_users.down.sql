@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+DROP INDEX IF EXISTS idx_tasks_creator_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignee_id, DROP COLUMN IF EXISTS creator_id;
+DROP TABLE IF EXISTS users;
\ No newline at end of file
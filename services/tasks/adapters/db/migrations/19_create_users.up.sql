@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name text NOT NULL,
+    email text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_users_email
+    ON users (lower(email));
+
+-- автор и исполнитель задачи; при удалении пользователя поля обнуляются
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS creator_id BIGINT NULL REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS assignee_id BIGINT NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_creator_id
+    ON tasks (creator_id)
+    WHERE creator_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_id
+    ON tasks (assignee_id)
+    WHERE assignee_id IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- ключи доступа к API: хранится только sha256 ключа, при удалении пользователя ключи удаляются
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    prefix text NOT NULL,
+    key_hash text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_used_at timestamptz NULL,
+    revoked_at timestamptz NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_api_keys_key_hash
+    ON api_keys (key_hash);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id
+    ON api_keys (user_id, id);
\ No newline at end of file
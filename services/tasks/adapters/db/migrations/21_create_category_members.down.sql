@@ -0,0 +1 @@
+DROP TABLE IF EXISTS category_members;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- роли пользователей в категориях: 1 viewer, 2 editor, 3 owner; действуют и во вложенных категориях
+CREATE TABLE IF NOT EXISTS category_members (
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role SMALLINT NOT NULL CHECK (role BETWEEN 1 AND 3),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (category_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_members_user_id
+    ON category_members (user_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_events_unsequenced;
+DROP INDEX IF EXISTS ux_task_events_seq;
+ALTER TABLE task_events DROP COLUMN IF EXISTS seq;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- seq - порядок событий для WatchTasks. id выдаётся при вставке, а транзакции
+-- коммитятся в другом порядке, поэтому seq проставляет по одному писателю
+-- SequenceTaskEvents уже закоммиченным событиям; NULL => ещё не упорядочено
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1
+    FROM information_schema.columns
+    WHERE table_name = 'task_events'
+      AND column_name = 'seq'
+  ) THEN
+ALTER TABLE task_events
+    ADD COLUMN seq BIGINT NULL;
+-- старые события уже закоммичены, их порядок - id
+UPDATE task_events SET seq = id;
+END IF;
+END$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_task_events_seq
+    ON task_events (seq);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_unsequenced
+    ON task_events (id)
+    WHERE seq IS NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- ClaimOutbox ищет более ранние неотправленные события того же агрегата
+CREATE INDEX IF NOT EXISTS idx_outbox_pending_aggregate
+    ON outbox (aggregate_id, id)
+    WHERE published_at IS NULL;
\ No newline at end of file
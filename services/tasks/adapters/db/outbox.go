@@ -0,0 +1,182 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Outbox
+
+// insertOutbox пишет доменное событие в outbox и доставки подписанным вебхукам
+// в транзакции изменения, автор берётся из ctx
+func insertOutbox(ctx context.Context, tx querier, typ string, aggregateID int64, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	e := core.OutboxEvent{
+		Type:        typ,
+		AggregateID: aggregateID,
+		Payload:     b,
+		Actor:       core.ActorFromContext(ctx),
+	}
+
+	const q = `
+		INSERT INTO outbox(type, aggregate_id, payload, actor)
+		VALUES ($1, $2, $3::jsonb, NULLIF($4, ''))
+		RETURNING id, created_at;
+	`
+	if err := tx.GetContext(ctx, &e, q, e.Type, e.AggregateID, string(e.Payload), e.Actor); err != nil {
+		return fmt.Errorf("insert outbox event: %w", err)
+	}
+
+	return insertDeliveries(ctx, tx, e)
+}
+
+// insertTaskOutbox пишет доменные события изменения задачи: само изменение
+// и отдельно смену статуса, на которую чаще всего подписываются
+func insertTaskOutbox(ctx context.Context, tx querier, taskID int64, typ core.TaskEventType, changes []core.FieldChange) error {
+	var outboxType string
+	switch typ {
+	case core.EventCreated:
+		outboxType = core.OutboxTaskCreated
+	case core.EventUpdated:
+		outboxType = core.OutboxTaskUpdated
+	case core.EventDeleted:
+		outboxType = core.OutboxTaskDeleted
+	case core.EventRestored:
+		outboxType = core.OutboxTaskRestored
+	case core.EventPurged:
+		outboxType = core.OutboxTaskPurged
+	default:
+		return fmt.Errorf("unknown task event type %d", typ)
+	}
+
+	if err := insertOutbox(ctx, tx, outboxType, taskID, core.TaskPayload{TaskID: taskID, Changes: changes}); err != nil {
+		return err
+	}
+
+	if typ != core.EventUpdated {
+		return nil
+	}
+	for _, c := range changes {
+		if c.Field == "status" {
+			p := core.TaskStatusPayload{TaskID: taskID, From: c.Old, To: c.New}
+			return insertOutbox(ctx, tx, core.OutboxTaskStatusChanged, taskID, p)
+		}
+	}
+	return nil
+}
+
+// outboxClaimLockKey - ключ advisory-блокировки, под которой relay берут события
+const outboxClaimLockKey int64 = 0x6f7574626f78 // "outbox"
+
+// ClaimOutbox берёт в аренду до limit событий, готовых к отправке, в порядке id:
+// откладывает их на lease и сразу коммитит. Событие не берётся, пока более раннее
+// событие того же агрегата отложено - после неудачи или в аренде у другого relay.
+// Relay берут события по одному, иначе второй не увидел бы аренду первого
+func (db *DB) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]core.OutboxEvent, error) {
+	if limit <= 0 {
+		limit = core.DefaultPageSize
+	}
+
+	// агрегат - тип до точки и aggregate_id, как в core.outboxAggregate
+	const q = `
+		UPDATE outbox
+		SET next_attempt_at = now() + $2 * interval '1 microsecond'
+		WHERE id IN (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.published_at IS NULL AND o.next_attempt_at <= now()
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id
+				  AND split_part(p.type, '.', 1) = split_part(o.type, '.', 1)
+				  AND p.id < o.id
+				  AND p.published_at IS NULL
+				  AND p.next_attempt_at > now()
+			  )
+			ORDER BY o.id
+			LIMIT $1
+		) AND published_at IS NULL
+		RETURNING id, type, aggregate_id, payload, COALESCE(actor, '') AS actor, created_at, attempts;
+	`
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin claim outbox: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, outboxClaimLockKey); err != nil {
+		return nil, fmt.Errorf("lock outbox claim: %w", err)
+	}
+
+	var out []core.OutboxEvent
+	if err := tx.SelectContext(ctx, &out, q, limit, lease.Microseconds()); err != nil {
+		return nil, fmt.Errorf("claim outbox: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit claim outbox: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	slices.SortFunc(out, func(a, b core.OutboxEvent) int { return cmp.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+// ReleaseOutbox снимает аренду с неотправленных событий, они снова готовы к отправке
+func (db *DB) ReleaseOutbox(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const q = `UPDATE outbox SET next_attempt_at = now() WHERE id = ANY($1) AND published_at IS NULL`
+	if _, err := db.q(ctx).ExecContext(ctx, q, ids); err != nil {
+		return fmt.Errorf("release outbox: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) MarkOutboxPublished(ctx context.Context, id int64) error {
+	const q = `UPDATE outbox SET published_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = $1`
+	if _, err := db.q(ctx).ExecContext(ctx, q, id); err != nil {
+		return fmt.Errorf("mark outbox published: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed записывает неудачную попытку и откладывает следующую на retryAfter
+func (db *DB) MarkOutboxFailed(ctx context.Context, id int64, retryAfter time.Duration, reason string) error {
+	const q = `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+		    next_attempt_at = now() + $2 * interval '1 microsecond',
+		    last_error = $3
+		WHERE id = $1
+	`
+	if _, err := db.q(ctx).ExecContext(ctx, q, id, retryAfter.Microseconds(), reason); err != nil {
+		return fmt.Errorf("mark outbox failed: %w", err)
+	}
+	return nil
+}
+
+// PurgeOutbox удаляет события, опубликованные раньше before;
+// unpublished => и неопубликованные, созданные раньше before
+func (db *DB) PurgeOutbox(ctx context.Context, before time.Time, unpublished bool) (int64, error) {
+	const q = `DELETE FROM outbox WHERE published_at < $1 OR ($2 AND published_at IS NULL AND created_at < $1)`
+	res, err := db.q(ctx).ExecContext(ctx, q, before, unpublished)
+	if err != nil {
+		return 0, fmt.Errorf("purge outbox: %w", err)
+	}
+	return res.RowsAffected()
+}
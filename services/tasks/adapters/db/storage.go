@@ -37,35 +37,57 @@ func (db *DB) Ping(ctx context.Context) error {
 
 // Categories
 
-func (db *DB) CreateCategory(ctx context.Context, name string) (core.Category, error) {
-	name = strings.TrimSpace(name)
+// categoryColumns - колонки categories в порядке полей core.Category
+const categoryColumns = `id, parent_id, name, COALESCE(description, '') AS description, COALESCE(color, '') AS color, COALESCE(icon, '') AS icon, position, created_at`
+
+func (db *DB) CreateCategory(ctx context.Context, in core.NewCategory) (core.Category, error) {
+	name := strings.TrimSpace(in.Name)
 	if name == "" {
 		return core.Category{}, core.ErrCategoryInvalidArgs
 	}
 
 	const q = `
-		INSERT INTO categories(name)
-		VALUES ($1)
-		RETURNING id, created_at;
+		INSERT INTO categories(name, parent_id, description, color, icon, position)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6)
+		RETURNING ` + categoryColumns + `;
 	`
 
-	var c core.Category
-	c.Name = name
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Category{}, fmt.Errorf("begin create category: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	if err := db.conn.QueryRowxContext(ctx, q, c.Name).Scan(&c.ID, &c.CreatedAt); err != nil {
+	var c core.Category
+	err = tx.GetContext(ctx, &c, q, name, in.ParentID, strings.TrimSpace(in.Description), in.Color, in.Icon, in.Position)
+	if err != nil {
 		if isUniqueViolation(err) {
 			return core.Category{}, core.ErrCategoryAlreadyExists
 		}
+		if isCheckViolation(err) {
+			return core.Category{}, core.ErrCategoryInvalidArgs
+		}
+		if isForeignKeyViolation(err) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
 		return core.Category{}, fmt.Errorf("insert category: %w", err)
 	}
+
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryCreated, c.ID, core.NewCategoryPayload(c)); err != nil {
+		return core.Category{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Category{}, fmt.Errorf("commit create category: %w", err)
+	}
 	return c, nil
 }
 
 func (db *DB) GetCategory(ctx context.Context, id int64) (core.Category, error) {
-	const q = `SELECT id, name, created_at FROM categories WHERE id = $1`
+	const q = `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1 AND deleted_at IS NULL`
 
 	var c core.Category
-	if err := db.conn.GetContext(ctx, &c, q, id); err != nil {
+	if err := db.q(ctx).GetContext(ctx, &c, q, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Category{}, core.ErrCategoryNotFound
 		}
@@ -74,97 +96,314 @@ func (db *DB) GetCategory(ctx context.Context, id int64) (core.Category, error)
 	return c, nil
 }
 
-func (db *DB) ListCategories(ctx context.Context) ([]core.Category, error) {
-	const q = `SELECT id, name, created_at FROM categories ORDER BY lower(name) ASC`
+// LockCategory читает категорию под FOR SHARE: до конца транзакции её
+// нельзя удалить или переместить
+func (db *DB) LockCategory(ctx context.Context, id int64) (core.Category, error) {
+	const q = `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1 AND deleted_at IS NULL FOR SHARE`
+
+	var c core.Category
+	if err := db.q(ctx).GetContext(ctx, &c, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
+		return core.Category{}, fmt.Errorf("lock category: %w", err)
+	}
+	return c, nil
+}
+
+// LockCategoryForUpdate читает категорию под FOR UPDATE перед её изменением
+func (db *DB) LockCategoryForUpdate(ctx context.Context, id int64) (core.Category, error) {
+	const q = `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	var c core.Category
+	if err := db.q(ctx).GetContext(ctx, &c, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
+		return core.Category{}, fmt.Errorf("lock category for update: %w", err)
+	}
+	return c, nil
+}
+
+func (db *DB) ListCategories(ctx context.Context, f core.ListCategoriesFilter) ([]core.Category, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по (position, lower(name), id); без курсора - с начала списка
+	q := `
+		SELECT ` + categoryColumns + `
+		FROM categories
+		WHERE deleted_at IS NULL
+		  AND ($1::bigint IS NULL OR (position, lower(name), id) > ($3::integer, lower($2::text), $1))
+		  AND ($5::bigint IS NULL OR id IN (` + readableCategoriesSQL(5) + `))
+		ORDER BY position ASC, lower(name) ASC, id ASC
+		LIMIT $4
+	`
+
+	var (
+		afterID       *int64
+		afterName     string
+		afterPosition int32
+	)
+	if f.After != nil {
+		afterID = &f.After.ID
+		afterName = f.After.Name
+		afterPosition = f.After.Position
+	}
 
 	var out []core.Category
-	if err := db.conn.SelectContext(ctx, &out, q); err != nil {
+	if err := db.q(ctx).SelectContext(ctx, &out, q, afterID, afterName, afterPosition, f.Limit, f.ReadableBy); err != nil {
 		return nil, fmt.Errorf("list categories: %w", err)
 	}
 	return out, nil
 }
 
-func (db *DB) UpdateCategory(ctx context.Context, id int64, name string) (core.Category, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
+// UpdateCategory перезаписывает редактируемые поля категории; parent_id меняет только MoveCategory
+func (db *DB) UpdateCategory(ctx context.Context, c core.Category) (core.Category, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.ID == 0 || c.Name == "" {
 		return core.Category{}, core.ErrCategoryInvalidArgs
 	}
 
 	const q = `
 		UPDATE categories
-		SET name = $2
-		WHERE id = $1
-		RETURNING id, name, created_at;
+		SET name = $2,
+		    description = NULLIF($3, ''),
+		    color = NULLIF($4, ''),
+		    icon = NULLIF($5, ''),
+		    position = $6
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + categoryColumns + `;
 	`
 
-	var c core.Category
-	if err := db.conn.GetContext(ctx, &c, q, id, name); err != nil {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Category{}, fmt.Errorf("begin update category: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var out core.Category
+	if err := tx.GetContext(ctx, &out, q, c.ID, c.Name, strings.TrimSpace(c.Description), c.Color, c.Icon, c.Position); err != nil {
 		if isUniqueViolation(err) {
 			return core.Category{}, core.ErrCategoryAlreadyExists
 		}
+		if isCheckViolation(err) {
+			return core.Category{}, core.ErrCategoryInvalidArgs
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Category{}, core.ErrCategoryNotFound
 		}
 		return core.Category{}, fmt.Errorf("update category: %w", err)
 	}
-	return c, nil
+
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryUpdated, out.ID, core.NewCategoryPayload(out)); err != nil {
+		return core.Category{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Category{}, fmt.Errorf("commit update category: %w", err)
+	}
+	return out, nil
 }
 
-func (db *DB) DeleteCategory(ctx context.Context, id int64) error {
-	const q = `DELETE FROM categories WHERE id = $1`
+// CategoryTaskCounts считает задачи категорий по статусам одним сгруппированным запросом
+func (db *DB) CategoryTaskCounts(ctx context.Context, ids []int64) (map[int64]core.TaskCounts, error) {
+	out := make(map[int64]core.TaskCounts, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	const q = `
+		SELECT category_id, status, count(*) AS n
+		FROM tasks
+		WHERE category_id = ANY($1) AND deleted_at IS NULL
+		GROUP BY category_id, status;
+	`
+
+	var rows []struct {
+		CategoryID int64           `db:"category_id"`
+		Status     core.TaskStatus `db:"status"`
+		N          int64           `db:"n"`
+	}
+	if err := db.q(ctx).SelectContext(ctx, &rows, q, ids); err != nil {
+		return nil, fmt.Errorf("count category tasks: %w", err)
+	}
+
+	for _, r := range rows {
+		counts := out[r.CategoryID]
+		counts.Add(r.Status, r.N)
+		out[r.CategoryID] = counts
+	}
+	return out, nil
+}
 
-	res, err := db.conn.ExecContext(ctx, q, id)
+// DeleteCategory в одной транзакции обрабатывает задачи категории по стратегии
+// и переносит категорию в корзину
+func (db *DB) DeleteCategory(ctx context.Context, id int64, d core.CategoryDeletion) error {
+	tx, err := db.begin(ctx)
 	if err != nil {
+		return fmt.Errorf("begin delete category: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked int64
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrCategoryNotFound
+		}
+		return fmt.Errorf("get category for delete: %w", err)
+	}
+
+	switch d.Strategy {
+	case core.DeleteFailIfNotEmpty:
+		var notEmpty bool
+		if err := tx.GetContext(ctx, &notEmpty, `SELECT EXISTS (SELECT 1 FROM tasks WHERE category_id = $1 AND deleted_at IS NULL)`, id); err != nil {
+			return fmt.Errorf("check category tasks: %w", err)
+		}
+		if notEmpty {
+			return core.ErrCategoryNotEmpty
+		}
+
+	case core.DeleteTasks:
+		// задачи уходят в корзину вместе с категорией
+		var tasks []core.Task
+		if err := tx.SelectContext(ctx, &tasks, `SELECT `+taskColumns+` FROM tasks WHERE category_id = $1 AND deleted_at IS NULL ORDER BY id FOR UPDATE`, id); err != nil {
+			return fmt.Errorf("get category tasks: %w", err)
+		}
+		for _, t := range tasks {
+			if err := deleteTaskTx(ctx, tx, t); err != nil {
+				return err
+			}
+		}
+
+	default:
+		// DeleteOrphan и DeleteReassign: переносим все задачи, включая задачи в корзине
+		var target *int64
+		if d.Strategy == core.DeleteReassign {
+			if err := tx.GetContext(ctx, &locked, `SELECT id FROM categories WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, d.TargetID); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return core.ErrCategoryNotFound
+				}
+				return fmt.Errorf("get target category: %w", err)
+			}
+			target = &d.TargetID
+		}
+
+		const moveQ = `
+			UPDATE tasks
+			SET category_id = $2, updated_at = now(), version = version + 1
+			WHERE category_id = $1
+			RETURNING id;
+		`
+		var moved []int64
+		if err := tx.SelectContext(ctx, &moved, moveQ, id, target); err != nil {
+			return fmt.Errorf("move category tasks: %w", err)
+		}
+		for _, taskID := range moved {
+			if err := insertTaskEvent(ctx, tx, taskID, core.EventUpdated, fieldChange("category_id", id, target)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// дочерние категории поднимаются на уровень удаляемой
+	const liftQ = `
+		UPDATE categories
+		SET parent_id = (SELECT parent_id FROM categories WHERE id = $1)
+		WHERE parent_id = $1
+		RETURNING ` + categoryColumns + `, deleted_at;
+	`
+	var lifted []core.Category
+	if err := tx.SelectContext(ctx, &lifted, liftQ, id); err != nil {
+		return fmt.Errorf("lift child categories: %w", err)
+	}
+	for _, c := range lifted {
+		// о категориях в корзине подписчики уже знают
+		if c.DeletedAt != nil {
+			continue
+		}
+		if err := insertOutbox(ctx, tx, core.OutboxCategoryMoved, c.ID, core.NewCategoryPayload(c)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET deleted_at = now() WHERE id = $1`, id); err != nil {
 		return fmt.Errorf("delete category: %w", err)
 	}
-	aff, _ := res.RowsAffected()
-	if aff == 0 {
-		return core.ErrCategoryNotFound
+
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryDeleted, id, core.CategoryPayload{CategoryID: id}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete category: %w", err)
 	}
 	return nil
 }
 
 // Tasks
 
-func (db *DB) CreateTask(ctx context.Context, categoryID *int64, name, description string) (core.Task, error) {
-	name = strings.TrimSpace(name)
+// taskColumns - колонки tasks в порядке полей core.Task
+const taskColumns = `id, category_id, parent_id, name, COALESCE(description, '') AS description, status, priority, due_at, created_at, updated_at, completed_at, archived_at, version, creator_id, assignee_id`
+
+func (db *DB) CreateTask(ctx context.Context, in core.NewTask) (core.Task, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Task{}, fmt.Errorf("begin insert task: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := createTaskTx(ctx, tx, in)
+	if err != nil {
+		return core.Task{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Task{}, fmt.Errorf("commit insert task: %w", err)
+	}
+	return t, nil
+}
+
+// createTaskTx вставляет задачу и пишет событие создания
+func createTaskTx(ctx context.Context, tx querier, in core.NewTask) (core.Task, error) {
+	name := strings.TrimSpace(in.Name)
 	if name == "" {
 		return core.Task{}, core.ErrTaskInvalidArgs
 	}
 
 	const q = `
-		INSERT INTO tasks(category_id, name, description, status)
-		VALUES ($1, $2, NULLIF($3, ''), $4)
-		RETURNING id, category_id, name, COALESCE(description, ''), status, created_at, updated_at;
+		INSERT INTO tasks(category_id, parent_id, name, description, status, priority, due_at, creator_id, assignee_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+		RETURNING ` + taskColumns + `;
 	`
 
 	status := core.TODO
 
 	var t core.Task
-	err := db.conn.QueryRowxContext(ctx, q, categoryID, name, strings.TrimSpace(description), int16(status)).
-		Scan(&t.ID, &t.CategoryID, &t.Name, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+	err := tx.GetContext(ctx, &t, q, in.CategoryID, in.ParentID, name, strings.TrimSpace(in.Description), int16(status), int16(in.Priority), in.DueAt, in.CreatorID, in.AssigneeID)
 
 	if err != nil {
 		if isForeignKeyViolation(err) {
-			return core.Task{}, core.ErrCategoryNotFound
+			return core.Task{}, taskForeignKeyErr(err)
 		}
 		if isCheckViolation(err) {
 			return core.Task{}, core.ErrTaskInvalidArgs
 		}
 		return core.Task{}, fmt.Errorf("insert task: %w", err)
 	}
+
+	if err := insertTaskEvent(ctx, tx, t.ID, core.EventCreated, taskChanges(nil, &t)); err != nil {
+		return core.Task{}, err
+	}
 	return t, nil
 }
 
 func (db *DB) GetTask(ctx context.Context, id int64) (core.Task, error) {
-	const q = `
-		SELECT id, category_id, name, COALESCE(description, '') AS description, status, created_at, updated_at
-		FROM tasks
-		WHERE id = $1;
-	`
+	const q = `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL`
 
 	var t core.Task
-	if err := db.conn.GetContext(ctx, &t, q, id); err != nil {
+	if err := db.q(ctx).GetContext(ctx, &t, q, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Task{}, core.ErrTaskNotFound
 		}
@@ -173,24 +412,118 @@ func (db *DB) GetTask(ctx context.Context, id int64) (core.Task, error) {
 	return t, nil
 }
 
+// LockTask читает задачу под FOR UPDATE до конца транзакции
+func (db *DB) LockTask(ctx context.Context, id int64) (core.Task, error) {
+	return lockTaskTx(ctx, db.q(ctx), id)
+}
+
 func (db *DB) ListTasks(ctx context.Context, f core.ListTasksFilter) ([]core.Task, error) {
 	if f.Limit <= 0 {
-		f.Limit = 50
+		f.Limit = core.DefaultPageSize
 	}
-	if f.Limit > 200 {
-		f.Limit = 200
+
+	var sb strings.Builder
+	where, args := tasksWhere(f)
+
+	sb.WriteString(`SELECT ` + taskColumns + ` FROM tasks WHERE ` + where)
+	args = tasksPage(&sb, args, f, "")
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, sb.String(), args...); err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	return out, nil
+}
+
+// SearchTasks - ListTasks с полнотекстовым поиском: добавляет ранг и подсвеченный фрагмент
+func (db *DB) SearchTasks(ctx context.Context, f core.ListTasksFilter) ([]core.TaskHit, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
 	}
+
+	var sb strings.Builder
+	where, args := tasksWhere(f)
+
+	args = append(args, f.Query)
+	query := fmt.Sprintf("websearch_to_tsquery('simple', $%d)", len(args))
+	rank := "ts_rank(search, " + query + ")"
+
+	snippet := "''"
+	if f.Highlight {
+		snippet = "ts_headline('simple', name || ' ' || COALESCE(description, ''), " + query +
+			", 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')"
+	}
+
+	sb.WriteString(`SELECT ` + taskColumns + `, ` + rank + ` AS rank, ` + snippet + ` AS snippet FROM tasks WHERE ` + where)
+	args = tasksPage(&sb, args, f, rank)
+
+	var out []core.TaskHit
+	if err := db.q(ctx).SelectContext(ctx, &out, sb.String(), args...); err != nil {
+		return nil, fmt.Errorf("search tasks: %w", err)
+	}
+	return out, nil
+}
+
+// tasksPage дописывает keyset по курсору, сортировку и LIMIT/OFFSET.
+// rank - выражение релевантности, нужно только для SortRelevance
+func tasksPage(sb *strings.Builder, args []any, f core.ListTasksFilter, rank string) []any {
 	if f.Offset < 0 {
 		f.Offset = 0
 	}
 
+	// keyset: строки строго после курсора в порядке сортировки
+	if f.After != nil {
+		n := len(args) + 1
+		switch f.Sort {
+		case core.SortPriorityDesc:
+			args = append(args, int16(f.After.Priority), f.After.CreatedAt, f.After.ID)
+			sb.WriteString(fmt.Sprintf(" AND (priority, created_at, id) < ($%d, $%d, $%d)", n, n+1, n+2))
+		case core.SortRelevance:
+			args = append(args, f.After.Rank, f.After.ID)
+			sb.WriteString(fmt.Sprintf(" AND (%s, id) < ($%d::real, $%d)", rank, n, n+1))
+		default:
+			args = append(args, f.After.CreatedAt, f.After.ID)
+			sb.WriteString(fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", n, n+1))
+		}
+	}
+
+	switch f.Sort {
+	case core.SortPriorityDesc:
+		sb.WriteString(" ORDER BY priority DESC, created_at DESC, id DESC")
+	case core.SortRelevance:
+		sb.WriteString(" ORDER BY rank DESC, id DESC")
+	default:
+		sb.WriteString(" ORDER BY created_at DESC, id DESC")
+	}
+
+	n := len(args) + 1
+	args = append(args, f.Limit, f.Offset)
+	sb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", n, n+1))
+
+	return args
+}
+
+// CountTasks считает задачи по фильтру без учёта пагинации
+func (db *DB) CountTasks(ctx context.Context, f core.ListTasksFilter) (int64, error) {
+	where, args := tasksWhere(f)
+
+	var total int64
+	if err := db.q(ctx).GetContext(ctx, &total, `SELECT count(*) FROM tasks WHERE `+where, args...); err != nil {
+		return 0, fmt.Errorf("count tasks: %w", err)
+	}
+	return total, nil
+}
+
+// tasksWhere собирает условия фильтра, плейсхолдеры начинаются с $1.
+// Задачи из корзины не попадают никогда
+func tasksWhere(f core.ListTasksFilter) (string, []any) {
 	var (
 		sb   strings.Builder
 		args []any
 		n    = 1
 	)
 
-	sb.WriteString(`SELECT id, category_id, name, COALESCE(description, '') AS description, status, created_at, updated_at FROM tasks WHERE 1=1`)
+	sb.WriteString("deleted_at IS NULL")
 
 	if f.Status != nil {
 		args = append(args, int16(*f.Status))
@@ -198,7 +531,33 @@ func (db *DB) ListTasks(ctx context.Context, f core.ListTasksFilter) ([]core.Tas
 		n++
 	}
 
-	if f.CategoryID != nil {
+	if f.Priority != nil {
+		args = append(args, int16(*f.Priority))
+		sb.WriteString(fmt.Sprintf(" AND priority = $%d", n))
+		n++
+	}
+
+	if f.ParentID != nil {
+		args = append(args, *f.ParentID)
+		sb.WriteString(fmt.Sprintf(" AND parent_id = $%d", n))
+		n++
+	}
+
+	if f.CategoryID != nil && f.IncludeSubcategories {
+		args = append(args, *f.CategoryID, maxTreeDepth)
+		sb.WriteString(fmt.Sprintf(` AND category_id IN (
+			WITH RECURSIVE subtree(id, depth) AS (
+				SELECT id, 0 FROM categories WHERE id = $%d AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.id, s.depth + 1
+				FROM categories c
+				JOIN subtree s ON c.parent_id = s.id
+				WHERE s.depth < $%d AND c.deleted_at IS NULL
+			)
+			SELECT id FROM subtree
+		)`, n, n+1))
+		n += 2
+	} else if f.CategoryID != nil {
 		args = append(args, *f.CategoryID)
 		sb.WriteString(fmt.Sprintf(" AND category_id = $%d", n))
 		n++
@@ -206,17 +565,85 @@ func (db *DB) ListTasks(ctx context.Context, f core.ListTasksFilter) ([]core.Tas
 		sb.WriteString(" AND category_id IS NULL")
 	}
 
-	args = append(args, f.Limit, f.Offset)
-	sb.WriteString(fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", n, n+1))
+	if f.CreatorID != nil {
+		args = append(args, *f.CreatorID)
+		sb.WriteString(fmt.Sprintf(" AND creator_id = $%d", n))
+		n++
+	}
 
-	var out []core.Task
-	if err := db.conn.SelectContext(ctx, &out, sb.String(), args...); err != nil {
-		return nil, fmt.Errorf("list tasks: %w", err)
+	if f.AssigneeID != nil {
+		args = append(args, *f.AssigneeID)
+		sb.WriteString(fmt.Sprintf(" AND assignee_id = $%d", n))
+		n++
+	} else if f.Unassigned {
+		sb.WriteString(" AND assignee_id IS NULL")
 	}
-	return out, nil
+
+	if f.DueBefore != nil {
+		args = append(args, *f.DueBefore)
+		sb.WriteString(fmt.Sprintf(" AND due_at < $%d", n))
+		n++
+	}
+
+	if f.DueAfter != nil {
+		args = append(args, *f.DueAfter)
+		sb.WriteString(fmt.Sprintf(" AND due_at >= $%d", n))
+		n++
+	}
+
+	if f.Overdue {
+		args = append(args, int16(core.Done), int16(core.Archived))
+		sb.WriteString(fmt.Sprintf(" AND due_at < now() AND status NOT IN ($%d, $%d)", n, n+1))
+		n += 2
+	}
+
+	if len(f.AnyTagIDs) > 0 {
+		args = append(args, f.AnyTagIDs)
+		sb.WriteString(fmt.Sprintf(" AND EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag_id = ANY($%d))", n))
+		n++
+	}
+
+	if len(f.AllTagIDs) > 0 {
+		// id уже без дубликатов, поэтому достаточно сравнить количество
+		args = append(args, f.AllTagIDs, len(f.AllTagIDs))
+		sb.WriteString(fmt.Sprintf(" AND (SELECT count(*) FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag_id = ANY($%d)) = $%d", n, n+1))
+		n += 2
+	}
+
+	if f.ReadableBy != nil {
+		args = append(args, *f.ReadableBy)
+		sb.WriteString(" AND " + readableTaskSQL(n))
+		n++
+	}
+
+	if f.Query != "" {
+		args = append(args, f.Query)
+		sb.WriteString(fmt.Sprintf(" AND search @@ websearch_to_tsquery('simple', $%d)", n))
+	}
+
+	return sb.String(), args
 }
 
 func (db *DB) UpdateTask(ctx context.Context, t core.Task) (core.Task, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Task{}, fmt.Errorf("begin update task: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	out, err := updateTaskTx(ctx, tx, t)
+	if err != nil {
+		return core.Task{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Task{}, fmt.Errorf("commit update task: %w", err)
+	}
+	return out, nil
+}
+
+// updateTaskTx перезаписывает задачу целиком и пишет изменённые поля в историю
+func updateTaskTx(ctx context.Context, tx querier, t core.Task) (core.Task, error) {
 	t.Name = strings.TrimSpace(t.Name)
 	if t.ID == 0 || t.Name == "" {
 		return core.Task{}, core.ErrTaskInvalidArgs
@@ -225,44 +652,127 @@ func (db *DB) UpdateTask(ctx context.Context, t core.Task) (core.Task, error) {
 	const q = `
 		UPDATE tasks
 		SET category_id = $2,
-		    name = $3,
-		    description = NULLIF($4, ''),
-		    status = $5,
-		    updated_at = now()
-		WHERE id = $1
-		RETURNING id, category_id, name, COALESCE(description, '') AS description, status, created_at, updated_at;
+		    parent_id = $3,
+		    name = $4,
+		    description = NULLIF($5, ''),
+		    status = $6,
+		    priority = $7,
+		    due_at = $8,
+		    updated_at = now(),
+		    version = version + 1,
+		    -- в SET status - ещё старое значение
+		    completed_at = CASE
+		        WHEN $6 = 2 AND status <> 2 THEN now()
+		        WHEN $6 IN (0, 1) THEN NULL
+		        ELSE completed_at
+		    END,
+		    archived_at = CASE
+		        WHEN $6 = 3 AND status <> 3 THEN now()
+		        WHEN $6 <> 3 THEN NULL
+		        ELSE archived_at
+		    END
+		WHERE id = $1 AND deleted_at IS NULL AND version = $9
+		RETURNING ` + taskColumns + `;
 	`
 
+	// старая версия строки нужна для аудита
+	old, err := lockTaskTx(ctx, tx, t.ID)
+	if err != nil {
+		return core.Task{}, err
+	}
+	// задачу изменили после того, как её прочитал вызывающий
+	if t.Version != 0 && t.Version != old.Version {
+		return core.Task{}, core.ErrConflict
+	}
+
 	var out core.Task
-	if err := db.conn.GetContext(ctx, &out, q, t.ID, t.CategoryID, t.Name, strings.TrimSpace(t.Description), int16(t.Status)); err != nil {
+	if err := tx.GetContext(ctx, &out, q, t.ID, t.CategoryID, t.ParentID, t.Name, strings.TrimSpace(t.Description), int16(t.Status), int16(t.Priority), t.DueAt, old.Version); err != nil {
 		if isForeignKeyViolation(err) {
-			return core.Task{}, core.ErrCategoryNotFound
+			return core.Task{}, taskForeignKeyErr(err)
 		}
 		if isCheckViolation(err) {
 			return core.Task{}, core.ErrTaskInvalidArgs
 		}
+		// строка заблокирована выше, так что без неё остаётся только смена версии
 		if errors.Is(err, sql.ErrNoRows) {
-			return core.Task{}, core.ErrTaskNotFound
+			return core.Task{}, core.ErrConflict
 		}
 		return core.Task{}, fmt.Errorf("update task: %w", err)
 	}
+
+	if changes := taskChanges(&old, &out); len(changes) > 0 {
+		if err := insertTaskEvent(ctx, tx, out.ID, core.EventUpdated, changes); err != nil {
+			return core.Task{}, err
+		}
+	}
+
 	return out, nil
 }
 
-func (db *DB) DeleteTask(ctx context.Context, id int64) error {
-	const q = `DELETE FROM tasks WHERE id = $1`
+// DeleteTask переносит задачу в корзину, version != 0 должна совпасть с текущей версией
+func (db *DB) DeleteTask(ctx context.Context, id, version int64) error {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin delete task: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	res, err := db.conn.ExecContext(ctx, q, id)
+	old, err := lockTaskTx(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("delete task: %w", err)
+		return err
 	}
-	aff, _ := res.RowsAffected()
-	if aff == 0 {
-		return core.ErrTaskNotFound
+	if version != 0 && version != old.Version {
+		return core.ErrConflict
+	}
+
+	if err := deleteTaskTx(ctx, tx, old); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete task: %w", err)
 	}
 	return nil
 }
 
+// lockTaskTx читает живую задачу и блокирует её до конца транзакции
+func lockTaskTx(ctx context.Context, tx querier, id int64) (core.Task, error) {
+	var t core.Task
+	if err := tx.GetContext(ctx, &t, `SELECT `+taskColumns+` FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Task{}, core.ErrTaskNotFound
+		}
+		return core.Task{}, fmt.Errorf("lock task: %w", err)
+	}
+	return t, nil
+}
+
+// deleteTaskTx переносит заблокированную FOR UPDATE задачу в корзину.
+// Подзадачи становятся задачами верхнего уровня, изменение parent_id пишется в их историю
+func deleteTaskTx(ctx context.Context, tx querier, old core.Task) error {
+	const detachQ = `
+		UPDATE tasks
+		SET parent_id = NULL, updated_at = now(), version = version + 1
+		WHERE parent_id = $1
+		RETURNING id;
+	`
+	var children []int64
+	if err := tx.SelectContext(ctx, &children, detachQ, old.ID); err != nil {
+		return fmt.Errorf("detach subtasks: %w", err)
+	}
+	for _, childID := range children {
+		if err := insertTaskEvent(ctx, tx, childID, core.EventUpdated, fieldChange("parent_id", old.ID, nil)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tasks SET deleted_at = now(), version = version + 1 WHERE id = $1`, old.ID); err != nil {
+		return fmt.Errorf("delete task: %w", err)
+	}
+
+	return insertTaskEvent(ctx, tx, old.ID, core.EventDeleted, taskChanges(&old, nil))
+}
+
 // pg helpers
 
 func isUniqueViolation(err error) bool {
@@ -279,3 +789,19 @@ func isCheckViolation(err error) bool {
 	var pgErr *pgconn.PgError
 	return errors.As(err, &pgErr) && pgErr.Code == "23514"
 }
+
+// taskForeignKeyErr различает несуществующую категорию, родительскую задачу и пользователя
+func taskForeignKeyErr(err error) error {
+	if isConstraint(err, "tasks_parent_id_fkey") {
+		return core.ErrTaskNotFound
+	}
+	if isConstraint(err, "tasks_creator_id_fkey") || isConstraint(err, "tasks_assignee_id_fkey") {
+		return core.ErrUserNotFound
+	}
+	return core.ErrCategoryNotFound
+}
+
+func isConstraint(err error, name string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.ConstraintName == name
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// maxTreeDepth ограничивает рекурсию по иерархии задач
+const maxTreeDepth = 100
+
+// taskTreeLockKey - ключ advisory-блокировки дерева подзадач
+const taskTreeLockKey int64 = 0x7461736b7472 // "tasktr"
+
+// LockTaskTree берёт transaction-level advisory-блокировку: смены родителя
+// с проверкой предков идут по одной. Вне транзакции блокировка сразу снимается
+func (db *DB) LockTaskTree(ctx context.Context) error {
+	if _, err := db.q(ctx).ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, taskTreeLockKey); err != nil {
+		return fmt.Errorf("lock task tree: %w", err)
+	}
+	return nil
+}
+
+// TaskAncestors возвращает id всех предков задачи, от родителя к корню
+func (db *DB) TaskAncestors(ctx context.Context, id int64) ([]int64, error) {
+	const q = `
+		WITH RECURSIVE ancestors(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM tasks WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, t.parent_id, a.depth + 1
+			FROM tasks t
+			JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth < $2
+		)
+		SELECT id FROM ancestors WHERE depth > 0 ORDER BY depth;
+	`
+
+	var out []int64
+	if err := db.q(ctx).SelectContext(ctx, &out, q, id, maxTreeDepth); err != nil {
+		return nil, fmt.Errorf("list task ancestors: %w", err)
+	}
+	return out, nil
+}
+
+// TaskSubtree возвращает задачу и всех её потомков, родители идут раньше детей
+func (db *DB) TaskSubtree(ctx context.Context, rootID int64) ([]core.Task, error) {
+	const q = `
+		WITH RECURSIVE subtree(id, depth) AS (
+			SELECT id, 0 FROM tasks WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, s.depth + 1
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE s.depth < $2 AND t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + `
+		FROM tasks
+		JOIN subtree USING (id)
+		ORDER BY subtree.depth, created_at, id;
+	`
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, q, rootID, maxTreeDepth); err != nil {
+		return nil, fmt.Errorf("get task subtree: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, core.ErrTaskNotFound
+	}
+	return out, nil
+}
+
+// CountOpenSubtasks считает прямые подзадачи, которые ещё не done/archived
+func (db *DB) CountOpenSubtasks(ctx context.Context, parentID int64) (int, error) {
+	const q = `SELECT count(*) FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL AND status NOT IN ($2, $3)`
+
+	var n int
+	if err := db.q(ctx).GetContext(ctx, &n, q, parentID, int16(core.Done), int16(core.Archived)); err != nil {
+		return 0, fmt.Errorf("count open subtasks: %w", err)
+	}
+	return n, nil
+}
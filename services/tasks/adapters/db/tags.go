@@ -0,0 +1,218 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Tags
+
+func (db *DB) CreateTag(ctx context.Context, name string) (core.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return core.Tag{}, core.ErrTagInvalidArgs
+	}
+
+	const q = `
+		INSERT INTO tags(name)
+		VALUES ($1)
+		RETURNING id, name, created_at;
+	`
+
+	var t core.Tag
+	if err := db.q(ctx).GetContext(ctx, &t, q, name); err != nil {
+		if isUniqueViolation(err) {
+			return core.Tag{}, core.ErrTagAlreadyExists
+		}
+		return core.Tag{}, fmt.Errorf("insert tag: %w", err)
+	}
+	return t, nil
+}
+
+func (db *DB) GetTag(ctx context.Context, id int64) (core.Tag, error) {
+	const q = `SELECT id, name, created_at FROM tags WHERE id = $1`
+
+	var t core.Tag
+	if err := db.q(ctx).GetContext(ctx, &t, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Tag{}, core.ErrTagNotFound
+		}
+		return core.Tag{}, fmt.Errorf("get tag: %w", err)
+	}
+	return t, nil
+}
+
+func (db *DB) ListTags(ctx context.Context, f core.ListTagsFilter) ([]core.Tag, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по (lower(name), id); без курсора - с начала списка
+	const q = `
+		SELECT id, name, created_at
+		FROM tags
+		WHERE $1::bigint IS NULL OR (lower(name), id) > (lower($2::text), $1)
+		ORDER BY lower(name) ASC, id ASC
+		LIMIT $3
+	`
+
+	var (
+		afterID   *int64
+		afterName string
+	)
+	if f.After != nil {
+		afterID = &f.After.ID
+		afterName = f.After.Name
+	}
+
+	var out []core.Tag
+	if err := db.q(ctx).SelectContext(ctx, &out, q, afterID, afterName, f.Limit); err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) RenameTag(ctx context.Context, id int64, name string) (core.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return core.Tag{}, core.ErrTagInvalidArgs
+	}
+
+	const q = `
+		UPDATE tags
+		SET name = $2
+		WHERE id = $1
+		RETURNING id, name, created_at;
+	`
+
+	var t core.Tag
+	if err := db.q(ctx).GetContext(ctx, &t, q, id, name); err != nil {
+		if isUniqueViolation(err) {
+			return core.Tag{}, core.ErrTagAlreadyExists
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Tag{}, core.ErrTagNotFound
+		}
+		return core.Tag{}, fmt.Errorf("rename tag: %w", err)
+	}
+	return t, nil
+}
+
+func (db *DB) DeleteTag(ctx context.Context, id int64) error {
+	const q = `DELETE FROM tags WHERE id = $1`
+
+	res, err := db.q(ctx).ExecContext(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return core.ErrTagNotFound
+	}
+	return nil
+}
+
+// MergeTags в одной транзакции вешает target на задачи с source тегами и удаляет source теги
+func (db *DB) MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (core.Tag, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Tag{}, fmt.Errorf("begin merge tags: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var target core.Tag
+	if err := tx.GetContext(ctx, &target, `SELECT id, name, created_at FROM tags WHERE id = $1 FOR UPDATE`, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Tag{}, core.ErrTagNotFound
+		}
+		return core.Tag{}, fmt.Errorf("get target tag: %w", err)
+	}
+
+	var found int
+	if err := tx.GetContext(ctx, &found, `SELECT count(*) FROM tags WHERE id = ANY($1)`, sourceIDs); err != nil {
+		return core.Tag{}, fmt.Errorf("check source tags: %w", err)
+	}
+	if found != len(sourceIDs) {
+		return core.Tag{}, core.ErrTagNotFound
+	}
+
+	const moveQ = `
+		INSERT INTO task_tags(task_id, tag_id)
+		SELECT DISTINCT task_id, $1::bigint
+		FROM task_tags
+		WHERE tag_id = ANY($2)
+		ON CONFLICT DO NOTHING;
+	`
+	if _, err := tx.ExecContext(ctx, moveQ, targetID, sourceIDs); err != nil {
+		return core.Tag{}, fmt.Errorf("move task tags: %w", err)
+	}
+
+	// task_tags источников удалятся каскадом
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id = ANY($1)`, sourceIDs); err != nil {
+		return core.Tag{}, fmt.Errorf("delete source tags: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Tag{}, fmt.Errorf("commit merge tags: %w", err)
+	}
+	return target, nil
+}
+
+// Task tags
+
+func (db *DB) AddTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error {
+	const q = `
+		INSERT INTO task_tags(task_id, tag_id)
+		SELECT $1, unnest($2::bigint[])
+		ON CONFLICT DO NOTHING;
+	`
+
+	if _, err := db.q(ctx).ExecContext(ctx, q, taskID, tagIDs); err != nil {
+		if isForeignKeyViolation(err) {
+			if isConstraint(err, "task_tags_task_id_fkey") {
+				return core.ErrTaskNotFound
+			}
+			return core.ErrTagNotFound
+		}
+		return fmt.Errorf("add task tags: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) RemoveTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error {
+	const q = `DELETE FROM task_tags WHERE task_id = $1 AND tag_id = ANY($2)`
+
+	if _, err := db.q(ctx).ExecContext(ctx, q, taskID, tagIDs); err != nil {
+		return fmt.Errorf("remove task tags: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) TaskTags(ctx context.Context, taskIDs []int64) (map[int64][]core.Tag, error) {
+	const q = `
+		SELECT tt.task_id, t.id, t.name, t.created_at
+		FROM task_tags tt
+		JOIN tags t ON t.id = tt.tag_id
+		WHERE tt.task_id = ANY($1)
+		ORDER BY lower(t.name) ASC, t.id ASC;
+	`
+
+	var rows []struct {
+		TaskID int64 `db:"task_id"`
+		core.Tag
+	}
+	if err := db.q(ctx).SelectContext(ctx, &rows, q, taskIDs); err != nil {
+		return nil, fmt.Errorf("list task tags: %w", err)
+	}
+
+	out := make(map[int64][]core.Tag, len(taskIDs))
+	for _, r := range rows {
+		out[r.TaskID] = append(out[r.TaskID], r.Tag)
+	}
+	return out, nil
+}
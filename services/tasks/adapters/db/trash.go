@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Trash
+
+func (db *DB) RestoreTask(ctx context.Context, id int64) (core.Task, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Task{}, fmt.Errorf("begin restore task: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// задача, удалённая вместе с категорией, возвращается только после неё;
+	// блокировка не даёт удалить категорию, пока задача восстанавливается
+	const categoryQ = `
+		SELECT c.deleted_at IS NOT NULL
+		FROM tasks t
+		JOIN categories c ON c.id = t.category_id
+		WHERE t.id = $1
+		FOR SHARE OF c;
+	`
+	var categoryDeleted bool
+	if err := tx.GetContext(ctx, &categoryDeleted, categoryQ, id); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return core.Task{}, fmt.Errorf("check task category: %w", err)
+	}
+	if categoryDeleted {
+		return core.Task{}, core.ErrCategoryDeleted
+	}
+
+	const q = `
+		UPDATE tasks
+		SET deleted_at = NULL, updated_at = now(), version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + taskColumns + `;
+	`
+
+	var t core.Task
+	if err := tx.GetContext(ctx, &t, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Task{}, core.ErrTaskNotFound
+		}
+		return core.Task{}, fmt.Errorf("restore task: %w", err)
+	}
+
+	if err := insertTaskEvent(ctx, tx, id, core.EventRestored, nil); err != nil {
+		return core.Task{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Task{}, fmt.Errorf("commit restore task: %w", err)
+	}
+	return t, nil
+}
+
+func (db *DB) RestoreCategory(ctx context.Context, id int64) (core.Category, error) {
+	const q = `
+		UPDATE categories
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + categoryColumns + `;
+	`
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Category{}, fmt.Errorf("begin restore category: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var c core.Category
+	if err := tx.GetContext(ctx, &c, q, id); err != nil {
+		// имя уже занято категорией вне корзины
+		if isUniqueViolation(err) {
+			return core.Category{}, core.ErrCategoryAlreadyExists
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Category{}, core.ErrCategoryNotFound
+		}
+		return core.Category{}, fmt.Errorf("restore category: %w", err)
+	}
+
+	if err := insertOutbox(ctx, tx, core.OutboxCategoryRestored, c.ID, core.NewCategoryPayload(c)); err != nil {
+		return core.Category{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Category{}, fmt.Errorf("commit restore category: %w", err)
+	}
+	return c, nil
+}
+
+func (db *DB) ListDeletedTasks(ctx context.Context, f core.ListDeletedFilter) ([]core.Task, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по (deleted_at, id) DESC; без курсора - с последних удалённых
+	q := `
+		SELECT ` + taskColumns + `, deleted_at
+		FROM tasks
+		WHERE deleted_at IS NOT NULL
+		  AND ($1::bigint IS NULL OR (deleted_at, id) < ($2::timestamptz, $1))
+		  AND ($4::bigint IS NULL OR ` + readableTaskSQL(4) + `)
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $3
+	`
+
+	afterID, afterAt := deletedCursor(f.After)
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, q, afterID, afterAt, f.Limit, f.ReadableBy); err != nil {
+		return nil, fmt.Errorf("list deleted tasks: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) ListDeletedCategories(ctx context.Context, f core.ListDeletedFilter) ([]core.Category, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	q := `
+		SELECT ` + categoryColumns + `, deleted_at
+		FROM categories
+		WHERE deleted_at IS NOT NULL
+		  AND ($1::bigint IS NULL OR (deleted_at, id) < ($2::timestamptz, $1))
+		  AND ($4::bigint IS NULL OR id IN (` + readableCategoriesSQL(4) + `))
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $3
+	`
+
+	afterID, afterAt := deletedCursor(f.After)
+
+	var out []core.Category
+	if err := db.q(ctx).SelectContext(ctx, &out, q, afterID, afterAt, f.Limit, f.ReadableBy); err != nil {
+		return nil, fmt.Errorf("list deleted categories: %w", err)
+	}
+	return out, nil
+}
+
+func deletedCursor(c *core.DeletedCursor) (*int64, time.Time) {
+	if c == nil {
+		return nil, time.Time{}
+	}
+	return &c.ID, c.DeletedAt
+}
+
+// PurgeDeleted в одной транзакции окончательно удаляет задачи и категории,
+// попавшие в корзину раньше before. Живые задачи удалённых категорий
+// остаются без категории, это изменение пишется в их историю
+func (db *DB) PurgeDeleted(ctx context.Context, before time.Time) (core.PurgeResult, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.PurgeResult{}, fmt.Errorf("begin purge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var res core.PurgeResult
+
+	var taskIDs []int64
+	if err := tx.SelectContext(ctx, &taskIDs, `DELETE FROM tasks WHERE deleted_at < $1 RETURNING id`, before); err != nil {
+		return core.PurgeResult{}, fmt.Errorf("purge tasks: %w", err)
+	}
+	for _, id := range taskIDs {
+		if err := insertTaskEvent(ctx, tx, id, core.EventPurged, nil); err != nil {
+			return core.PurgeResult{}, err
+		}
+	}
+	res.Tasks = int64(len(taskIDs))
+
+	// RETURNING отдаёт уже новое значение category_id, поэтому старое берём из categories
+	const detachQ = `
+		UPDATE tasks t
+		SET category_id = NULL, updated_at = now(), version = t.version + 1
+		FROM categories c
+		WHERE c.id = t.category_id AND c.deleted_at < $1
+		RETURNING t.id, c.id AS category_id;
+	`
+
+	var detached []struct {
+		ID         int64 `db:"id"`
+		CategoryID int64 `db:"category_id"`
+	}
+	if err := tx.SelectContext(ctx, &detached, detachQ, before); err != nil {
+		return core.PurgeResult{}, fmt.Errorf("detach purged categories: %w", err)
+	}
+	for _, d := range detached {
+		if err := insertTaskEvent(ctx, tx, d.ID, core.EventUpdated, fieldChange("category_id", d.CategoryID, nil)); err != nil {
+			return core.PurgeResult{}, err
+		}
+	}
+
+	var categoryIDs []int64
+	if err := tx.SelectContext(ctx, &categoryIDs, `DELETE FROM categories WHERE deleted_at < $1 RETURNING id`, before); err != nil {
+		return core.PurgeResult{}, fmt.Errorf("purge categories: %w", err)
+	}
+	for _, id := range categoryIDs {
+		if err := insertOutbox(ctx, tx, core.OutboxCategoryPurged, id, core.CategoryPayload{CategoryID: id}); err != nil {
+			return core.PurgeResult{}, err
+		}
+	}
+	res.Categories = int64(len(categoryIDs))
+
+	if err := tx.Commit(); err != nil {
+		return core.PurgeResult{}, fmt.Errorf("commit purge: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Unit of work
+
+type txKey struct{}
+
+// querier - общие методы *sqlx.DB и *sqlx.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// WithTx выполняет fn в одной транзакции. Методы DB, вызванные с ctx из fn,
+// работают в этой транзакции, ошибка fn откатывает её целиком.
+// Вложенный WithTx переиспользует внешнюю транзакцию
+func (db *DB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin unit of work: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit unit of work: %w", err)
+	}
+	return nil
+}
+
+// q возвращает транзакцию WithTx из ctx или соединение
+func (db *DB) q(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db.conn
+}
+
+// txn - транзакция одного метода DB. Внутри WithTx это транзакция WithTx,
+// тогда Commit и Rollback ничего не делают: её завершает WithTx
+type txn struct {
+	*sqlx.Tx
+	nested bool
+}
+
+func (db *DB) begin(ctx context.Context) (txn, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return txn{Tx: tx, nested: true}, nil
+	}
+
+	tx, err := db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return txn{}, err
+	}
+	return txn{Tx: tx}, nil
+}
+
+func (t txn) Commit() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t txn) Rollback() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Users
+
+// userColumns - колонки users в порядке полей core.User
+const userColumns = `id, name, email, created_at`
+
+func (db *DB) CreateUser(ctx context.Context, in core.NewUser) (core.User, error) {
+	const q = `
+		INSERT INTO users(name, email)
+		VALUES ($1, $2)
+		RETURNING ` + userColumns + `;
+	`
+
+	var u core.User
+	if err := db.q(ctx).GetContext(ctx, &u, q, in.Name, in.Email); err != nil {
+		if isUniqueViolation(err) {
+			return core.User{}, core.ErrUserAlreadyExists
+		}
+		return core.User{}, fmt.Errorf("insert user: %w", err)
+	}
+	return u, nil
+}
+
+func (db *DB) GetUser(ctx context.Context, id int64) (core.User, error) {
+	const q = `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	var u core.User
+	if err := db.q(ctx).GetContext(ctx, &u, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.User{}, core.ErrUserNotFound
+		}
+		return core.User{}, fmt.Errorf("get user: %w", err)
+	}
+	return u, nil
+}
+
+// LockUser читает пользователя под FOR SHARE: до конца транзакции его нельзя удалить
+func (db *DB) LockUser(ctx context.Context, id int64) (core.User, error) {
+	const q = `SELECT ` + userColumns + ` FROM users WHERE id = $1 FOR SHARE`
+
+	var u core.User
+	if err := db.q(ctx).GetContext(ctx, &u, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.User{}, core.ErrUserNotFound
+		}
+		return core.User{}, fmt.Errorf("lock user: %w", err)
+	}
+	return u, nil
+}
+
+func (db *DB) ListUsers(ctx context.Context, f core.ListUsersFilter) ([]core.User, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по id; без курсора - с начала списка
+	const q = `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE ($1::bigint IS NULL OR id > $1)
+		ORDER BY id
+		LIMIT $2
+	`
+
+	var afterID *int64
+	if f.After != nil {
+		afterID = &f.After.ID
+	}
+
+	var out []core.User
+	if err := db.q(ctx).SelectContext(ctx, &out, q, afterID, f.Limit); err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteUser в одной транзакции снимает пользователя со всех задач, включая
+// задачи в корзине, и удаляет его; creator_id обнуляет внешний ключ
+func (db *DB) DeleteUser(ctx context.Context, id int64) error {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin delete user: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked int64
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrUserNotFound
+		}
+		return fmt.Errorf("get user for delete: %w", err)
+	}
+
+	const unassignQ = `
+		UPDATE tasks
+		SET assignee_id = NULL, updated_at = now(), version = version + 1
+		WHERE assignee_id = $1
+		RETURNING id;
+	`
+	var unassigned []int64
+	if err := tx.SelectContext(ctx, &unassigned, unassignQ, id); err != nil {
+		return fmt.Errorf("unassign user tasks: %w", err)
+	}
+	for _, taskID := range unassigned {
+		if err := insertTaskEvent(ctx, tx, taskID, core.EventUpdated, fieldChange("assignee_id", id, nil)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete user: %w", err)
+	}
+	return nil
+}
+
+// AssignTask меняет исполнителя задачи и пишет изменение в историю
+func (db *DB) AssignTask(ctx context.Context, id int64, assigneeID *int64, version int64) (core.Task, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return core.Task{}, fmt.Errorf("begin assign task: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	old, err := lockTaskTx(ctx, tx, id)
+	if err != nil {
+		return core.Task{}, err
+	}
+	if version != 0 && version != old.Version {
+		return core.Task{}, core.ErrConflict
+	}
+
+	const q = `
+		UPDATE tasks
+		SET assignee_id = $2, updated_at = now(), version = version + 1
+		WHERE id = $1
+		RETURNING ` + taskColumns + `;
+	`
+
+	var t core.Task
+	if err := tx.GetContext(ctx, &t, q, id, assigneeID); err != nil {
+		if isForeignKeyViolation(err) {
+			return core.Task{}, core.ErrUserNotFound
+		}
+		return core.Task{}, fmt.Errorf("assign task: %w", err)
+	}
+
+	if err := insertTaskEvent(ctx, tx, id, core.EventUpdated, taskChanges(&old, &t)); err != nil {
+		return core.Task{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return core.Task{}, fmt.Errorf("commit assign task: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Watch
+
+// taskEventsSeqLockKey - ключ advisory-блокировки, под которой seq проставляет один писатель
+const taskEventsSeqLockKey int64 = 0x7365717565766e74 // "sequevnt"
+
+// SequenceTaskEvents проставляет seq закоммиченным событиям без него по возрастанию id
+// и возвращает, скольким. Писатель один за раз, поэтому seq коммитятся строго по
+// возрастанию и читатель по seq не перепрыгнет событие, которое появится позже.
+// Если seq уже проставляет другой экземпляр, ничего не делает
+func (db *DB) SequenceTaskEvents(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = core.DefaultPageSize
+	}
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin sequence task events: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock($1)`, taskEventsSeqLockKey); err != nil {
+		return 0, fmt.Errorf("lock task events seq: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	// снимок запроса берётся после блокировки и видит seq предыдущего писателя
+	const q = `
+		WITH pending AS (
+			SELECT id, row_number() OVER (ORDER BY id) AS n
+			FROM task_events
+			WHERE seq IS NULL
+			ORDER BY id
+			LIMIT $1
+		), base AS (
+			SELECT COALESCE(max(seq), 0) AS seq FROM task_events
+		)
+		UPDATE task_events e
+		SET seq = base.seq + pending.n
+		FROM pending, base
+		WHERE e.id = pending.id;
+	`
+	res, err := tx.ExecContext(ctx, q, limit)
+	if err != nil {
+		return 0, fmt.Errorf("sequence task events: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("sequence task events rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit sequence task events: %w", err)
+	}
+	return int(n), nil
+}
+
+// TaskEventsAfter - упорядоченные события всех задач после события afterID по возрастанию seq.
+// Если у afterID ещё нет seq, отсчёт идёт от последнего упорядоченного события с меньшим id
+func (db *DB) TaskEventsAfter(ctx context.Context, afterID int64, limit int) ([]core.TaskEvent, error) {
+	if limit <= 0 {
+		limit = core.DefaultPageSize
+	}
+
+	const q = `
+		SELECT id, task_id, type, COALESCE(actor, '') AS actor, changes, created_at
+		FROM task_events
+		WHERE seq > COALESCE(
+			(SELECT seq FROM task_events WHERE id = $1),
+			(SELECT max(seq) FROM task_events WHERE id <= $1),
+			0
+		)
+		ORDER BY seq
+		LIMIT $2
+	`
+	return db.selectTaskEvents(ctx, q, afterID, limit)
+}
+
+// LastTaskEventID - id последнего упорядоченного события, 0 если событий нет
+func (db *DB) LastTaskEventID(ctx context.Context) (int64, error) {
+	const q = `SELECT COALESCE((SELECT id FROM task_events WHERE seq IS NOT NULL ORDER BY seq DESC LIMIT 1), 0)`
+
+	var id int64
+	if err := db.q(ctx).GetContext(ctx, &id, q); err != nil {
+		return 0, fmt.Errorf("last task event id: %w", err)
+	}
+	return id, nil
+}
+
+// TasksByIDs - задачи по id вместе с лежащими в корзине; окончательно удалённых в ответе нет
+func (db *DB) TasksByIDs(ctx context.Context, ids []int64) ([]core.Task, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	const q = `SELECT ` + taskColumns + `, deleted_at FROM tasks WHERE id = ANY($1)`
+
+	var out []core.Task
+	if err := db.q(ctx).SelectContext(ctx, &out, q, ids); err != nil {
+		return nil, fmt.Errorf("tasks by ids: %w", err)
+	}
+	return out, nil
+}
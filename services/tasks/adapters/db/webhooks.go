@@ -0,0 +1,255 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Webhooks
+
+// webhookColumns - колонки webhooks; event_types как JSON, pgx stdlib не сканирует text[] в []string
+const webhookColumns = `id, url, secret, to_json(event_types) AS event_types, category_id, created_at`
+
+// webhookRow - строка webhooks до разбора event_types
+type webhookRow struct {
+	ID         int64     `db:"id"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []byte    `db:"event_types"`
+	CategoryID *int64    `db:"category_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (r webhookRow) webhook() (core.Webhook, error) {
+	w := core.Webhook{
+		ID:         r.ID,
+		URL:        r.URL,
+		Secret:     r.Secret,
+		CategoryID: r.CategoryID,
+		CreatedAt:  r.CreatedAt,
+	}
+	if err := json.Unmarshal(r.EventTypes, &w.EventTypes); err != nil {
+		return core.Webhook{}, fmt.Errorf("unmarshal webhook event types: %w", err)
+	}
+	return w, nil
+}
+
+func (db *DB) CreateWebhook(ctx context.Context, in core.NewWebhook) (core.Webhook, error) {
+	eventTypes := in.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	const q = `
+		INSERT INTO webhooks(url, secret, event_types, category_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookColumns + `;
+	`
+
+	var r webhookRow
+	if err := db.q(ctx).GetContext(ctx, &r, q, in.URL, in.Secret, eventTypes, in.CategoryID); err != nil {
+		if isForeignKeyViolation(err) {
+			return core.Webhook{}, core.ErrCategoryNotFound
+		}
+		return core.Webhook{}, fmt.Errorf("insert webhook: %w", err)
+	}
+	return r.webhook()
+}
+
+func (db *DB) GetWebhook(ctx context.Context, id int64) (core.Webhook, error) {
+	const q = `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var r webhookRow
+	if err := db.q(ctx).GetContext(ctx, &r, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Webhook{}, core.ErrWebhookNotFound
+		}
+		return core.Webhook{}, fmt.Errorf("get webhook: %w", err)
+	}
+	return r.webhook()
+}
+
+func (db *DB) ListWebhooks(ctx context.Context, f core.ListWebhooksFilter) ([]core.Webhook, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по id; без курсора - с начала списка
+	const q = `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE ($1::bigint IS NULL OR id > $1)
+		ORDER BY id
+		LIMIT $2
+	`
+
+	var afterID *int64
+	if f.After != nil {
+		afterID = &f.After.ID
+	}
+
+	var rows []webhookRow
+	if err := db.q(ctx).SelectContext(ctx, &rows, q, afterID, f.Limit); err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	out := make([]core.Webhook, 0, len(rows))
+	for _, r := range rows {
+		w, err := r.webhook()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, nil
+}
+
+func (db *DB) DeleteWebhook(ctx context.Context, id int64) error {
+	res, err := db.q(ctx).ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	if n == 0 {
+		return core.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Deliveries
+
+// deliveryColumns - колонки webhook_deliveries в порядке полей core.WebhookDelivery
+const deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, COALESCE(response_code, 0) AS response_code, COALESCE(last_error, '') AS last_error, created_at, next_attempt_at, delivered_at`
+
+// insertDeliveries ставит событие в очередь всем вебхукам, подписанным на его тип
+// и категорию. Категория задачи берётся на момент события, у событий категорий
+// категория - сам агрегат
+func insertDeliveries(ctx context.Context, tx querier, e core.OutboxEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	kind, _, _ := strings.Cut(e.Type, ".")
+
+	const q = `
+		INSERT INTO webhook_deliveries(webhook_id, event_id, event_type, payload)
+		SELECT w.id, $1, $2, $3::jsonb
+		FROM webhooks w
+		WHERE (cardinality(w.event_types) = 0 OR $2 = ANY(w.event_types))
+		  AND (w.category_id IS NULL OR w.category_id = CASE
+		      WHEN $4 = 'category' THEN $5
+		      ELSE (SELECT category_id FROM tasks WHERE id = $5)
+		  END);
+	`
+	if _, err := tx.ExecContext(ctx, q, e.ID, e.Type, string(body), kind, e.AggregateID); err != nil {
+		return fmt.Errorf("insert webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) ListDeliveries(ctx context.Context, f core.ListDeliveriesFilter) ([]core.WebhookDelivery, error) {
+	if f.Limit <= 0 {
+		f.Limit = core.DefaultPageSize
+	}
+
+	// keyset по id DESC; без курсора - с самой новой доставки
+	const q = `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE ($1::bigint = 0 OR webhook_id = $1)
+		  AND ($2::smallint IS NULL OR status = $2)
+		  AND ($3::bigint IS NULL OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	var afterID *int64
+	if f.After != nil {
+		afterID = &f.After.ID
+	}
+
+	var out []core.WebhookDelivery
+	if err := db.q(ctx).SelectContext(ctx, &out, q, f.WebhookID, f.Status, afterID, f.Limit); err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return out, nil
+}
+
+// ClaimDeliveries одним запросом выбирает готовые доставки и переносит их
+// next_attempt_at на lease вперёд: другой worker их не возьмёт, а при падении
+// доставка вернётся в очередь. Отправка идёт уже вне транзакции
+func (db *DB) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]core.PendingDelivery, error) {
+	const q = `
+		WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET next_attempt_at = now() + $2 * interval '1 microsecond'
+			WHERE id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE status = 0 AND next_attempt_at <= now()
+				ORDER BY next_attempt_at, id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + deliveryColumns + `
+		)
+		SELECT c.*, w.url, w.secret
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id
+		ORDER BY c.id;
+	`
+
+	var out []core.PendingDelivery
+	if err := db.q(ctx).SelectContext(ctx, &out, q, limit, lease.Microseconds()); err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	return out, nil
+}
+
+func (db *DB) MarkDeliveryDelivered(ctx context.Context, id int64, code int) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = 1, attempts = attempts + 1, response_code = $2, last_error = NULL, delivered_at = now()
+		WHERE id = $1
+	`
+	if _, err := db.q(ctx).ExecContext(ctx, q, id, code); err != nil {
+		return fmt.Errorf("mark delivery delivered: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) MarkDeliveryFailed(ctx context.Context, id int64, code int, retryAfter time.Duration, reason string, dead bool) error {
+	const q = `
+		UPDATE webhook_deliveries
+		SET status = CASE WHEN $5 THEN 2 ELSE 0 END,
+		    attempts = attempts + 1,
+		    response_code = NULLIF($2, 0),
+		    next_attempt_at = now() + $3 * interval '1 microsecond',
+		    last_error = $4
+		WHERE id = $1
+	`
+	if _, err := db.q(ctx).ExecContext(ctx, q, id, code, retryAfter.Microseconds(), reason, dead); err != nil {
+		return fmt.Errorf("mark delivery failed: %w", err)
+	}
+	return nil
+}
+
+// PurgeDeliveries удаляет доставленные и dead доставки, созданные раньше before
+func (db *DB) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	res, err := db.q(ctx).ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE status <> 0 AND created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge webhook deliveries: %w", err)
+	}
+	return res.RowsAffected()
+}
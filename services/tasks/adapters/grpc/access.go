@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Category members
+
+func (s *Server) SetCategoryMember(ctx context.Context, req *taskspb.SetCategoryMemberRequest) (*taskspb.CategoryMember, error) {
+	if req == nil || req.GetCategoryId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid category_id")
+	}
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	role := core.Role(req.GetRole())
+	if !role.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid role")
+	}
+
+	m, err := s.service.SetCategoryMember(ctx, req.GetCategoryId(), req.GetUserId(), role)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return categoryMemberToPB(m), nil
+}
+
+func (s *Server) RemoveCategoryMember(ctx context.Context, req *taskspb.RemoveCategoryMemberRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetCategoryId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid category_id")
+	}
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.service.RemoveCategoryMember(ctx, req.GetCategoryId(), req.GetUserId()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListCategoryMembers(ctx context.Context, req *taskspb.ListCategoryMembersRequest) (*taskspb.ListCategoryMembersResponse, error) {
+	if req == nil || req.GetCategoryId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid category_id")
+	}
+
+	members, err := s.service.ListCategoryMembers(ctx, req.GetCategoryId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListCategoryMembersResponse{Members: make([]*taskspb.CategoryMember, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, categoryMemberToPB(m))
+	}
+
+	return resp, nil
+}
+
+// Helpers
+
+// categoryMemberToPB - значения core.Role совпадают с CategoryRole
+func categoryMemberToPB(m core.CategoryMember) *taskspb.CategoryMember {
+	return &taskspb.CategoryMember{
+		CategoryId: m.CategoryID,
+		UserId:     m.UserID,
+		Role:       taskspb.CategoryRole(m.Role),
+		CreatedAt:  timestamppb.New(m.CreatedAt),
+	}
+}
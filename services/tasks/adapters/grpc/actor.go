@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// actorHeader - metadata с автором изменений для аудита
+const actorHeader = "x-actor"
+
+// userIDHeader - metadata с id пользователя, от имени которого идёт запрос
+const userIDHeader = "x-user-id"
+
+// ActorInterceptor переносит x-actor и x-user-id из metadata запроса в контекст core.
+// После аутентификации они берутся из вызывающего, а заголовки игнорируются
+func ActorInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if _, ok := core.PrincipalFromContext(ctx); ok {
+		return handler(ctx, req)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(actorHeader); len(v) > 0 {
+			if actor := strings.TrimSpace(v[0]); actor != "" {
+				ctx = core.WithActor(ctx, actor)
+			}
+		}
+		if v := md.Get(userIDHeader); len(v) > 0 {
+			id, err := strconv.ParseInt(strings.TrimSpace(v[0]), 10, 64)
+			if err != nil || id <= 0 {
+				return nil, status.Error(codes.InvalidArgument, "invalid "+userIDHeader)
+			}
+			ctx = core.WithUserID(ctx, id)
+		}
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// API keys
+
+func (s *Server) CreateApiKey(ctx context.Context, req *taskspb.CreateApiKeyRequest) (*taskspb.CreateApiKeyResponse, error) {
+	if req == nil || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	k, key, err := s.service.CreateAPIKey(ctx, core.NewAPIKey{UserID: req.GetUserId(), Name: req.GetName()})
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &taskspb.CreateApiKeyResponse{ApiKey: apiKeyToPB(k), Key: key}, nil
+}
+
+func (s *Server) ListApiKeys(ctx context.Context, req *taskspb.ListApiKeysRequest) (*taskspb.ListApiKeysResponse, error) {
+	if req == nil || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	f := core.ListAPIKeysFilter{UserID: req.GetUserId(), Limit: int(req.GetPageSize())}
+	if req.GetPageToken() != "" {
+		var cur core.APIKeyCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListAPIKeys(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListApiKeysResponse{ApiKeys: make([]*taskspb.ApiKey, 0, len(page.Keys))}
+	for _, k := range page.Keys {
+		resp.ApiKeys = append(resp.ApiKeys, apiKeyToPB(k))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) RevokeApiKey(ctx context.Context, req *taskspb.RevokeApiKeyRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	if err := s.service.RevokeAPIKey(ctx, req.GetId()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Helpers
+
+func apiKeyToPB(k core.APIKey) *taskspb.ApiKey {
+	out := &taskspb.ApiKey{
+		Id:        k.ID,
+		UserId:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.LastUsedAt != nil {
+		out.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		out.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// authorizationHeader - metadata с JWT: "Bearer <token>"
+const authorizationHeader = "authorization"
+
+// apiKeyHeader - metadata с API-ключом
+const apiKeyHeader = "x-api-key"
+
+// TokenVerifier проверяет JWT и возвращает вызывающего
+type TokenVerifier interface {
+	Verify(token string) (core.Principal, error)
+}
+
+// Auth - аутентификация запросов по JWT или API-ключу. Вызывающий кладётся
+// в контекст core, без него запрос отклоняется с codes.Unauthenticated
+type Auth struct {
+	log     *slog.Logger
+	service *core.Service
+	jwt     TokenVerifier // nil => JWT не принимаются
+	public  map[string]bool
+}
+
+// NewAuth создаёт аутентификацию; методы из public (полные имена, "/pkg.Service/Method")
+// доступны без неё
+func NewAuth(log *slog.Logger, service *core.Service, jwt TokenVerifier, public []string) *Auth {
+	a := &Auth{log: log, service: service, jwt: jwt, public: make(map[string]bool, len(public))}
+	for _, m := range public {
+		a.public[m] = true
+	}
+	return a
+}
+
+// PublicMethods - методы, которые можно открыть без аутентификации: Ping сервисов и reflection
+func PublicMethods(ping, reflection bool) []string {
+	var out []string
+	if ping {
+		out = append(out,
+			taskspb.CategoriesService_Ping_FullMethodName,
+			taskspb.TasksService_Ping_FullMethodName,
+			taskspb.TagsService_Ping_FullMethodName,
+		)
+	}
+	if reflection {
+		out = append(out,
+			reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+			reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+		)
+	}
+	return out
+}
+
+func (a *Auth) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Auth) Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate проверяет authorization или x-api-key; заданы оба => запрос отклоняется,
+// чтобы было однозначно, от чьего имени он идёт
+func (a *Auth) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.public[method] {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	bearer := firstValue(md, authorizationHeader)
+	apiKey := firstValue(md, apiKeyHeader)
+
+	var (
+		p   core.Principal
+		err error
+	)
+	switch {
+	case bearer != "" && apiKey != "":
+		return nil, status.Error(codes.Unauthenticated, "use either "+authorizationHeader+" or "+apiKeyHeader)
+	case bearer != "":
+		scheme, token, ok := strings.Cut(bearer, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			return nil, status.Error(codes.Unauthenticated, "malformed "+authorizationHeader)
+		}
+		if a.jwt == nil {
+			return nil, status.Error(codes.Unauthenticated, "jwt is not accepted")
+		}
+		if p, err = a.jwt.Verify(strings.TrimSpace(token)); err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if p, err = a.service.AuthenticateToken(ctx, p); err != nil {
+			if errors.Is(err, core.ErrUnauthenticated) {
+				return nil, status.Error(codes.Unauthenticated, "token subject is not a user")
+			}
+			a.log.Error("token authentication failed", "error", err)
+			return nil, status.Error(codes.Internal, "authentication failed")
+		}
+	case apiKey != "":
+		if p, err = a.service.AuthenticateAPIKey(ctx, apiKey); err != nil {
+			if errors.Is(err, core.ErrUnauthenticated) {
+				return nil, status.Error(codes.Unauthenticated, "invalid api key")
+			}
+			a.log.Error("api key authentication failed", "error", err)
+			return nil, status.Error(codes.Internal, "authentication failed")
+		}
+	default:
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	return core.WithPrincipal(ctx, p), nil
+}
+
+// authStream подменяет контекст потока на контекст с вызывающим
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return strings.TrimSpace(v[0])
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Batch
+
+func (s *Server) BatchCreateTasks(ctx context.Context, req *taskspb.BatchCreateTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	if req == nil || len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items cannot be empty")
+	}
+	mode, err := pbBatchModeToCore(req.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items := make([]core.NewTask, 0, len(req.GetItems()))
+	for i, it := range req.GetItems() {
+		in, err := newTaskFromPB(it)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: %v", i, err)
+		}
+		items = append(items, in)
+	}
+
+	res, err := s.service.BatchCreateTasks(ctx, items, mode)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return s.batchToPB(res), nil
+}
+
+func (s *Server) BatchUpdateTasks(ctx context.Context, req *taskspb.BatchUpdateTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	if req == nil || len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items cannot be empty")
+	}
+	mode, err := pbBatchModeToCore(req.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items := make([]core.TaskPatchItem, 0, len(req.GetItems()))
+	for i, it := range req.GetItems() {
+		if it.GetId() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: invalid id", i)
+		}
+		patch, err := taskPatchFromPB(it)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: %v", i, err)
+		}
+		items = append(items, core.TaskPatchItem{ID: it.GetId(), Patch: patch})
+	}
+
+	res, err := s.service.BatchPatchTasks(ctx, items, mode)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return s.batchToPB(res), nil
+}
+
+func (s *Server) BatchDeleteTasks(ctx context.Context, req *taskspb.BatchDeleteTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	if req == nil || len(req.GetIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids cannot be empty")
+	}
+	mode, err := pbBatchModeToCore(req.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := s.service.BatchDeleteTasks(ctx, req.GetIds(), mode)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return s.batchToPB(res), nil
+}
+
+func (s *Server) BulkUpdateStatus(ctx context.Context, req *taskspb.BulkUpdateStatusRequest) (*taskspb.BatchTasksResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	mode, err := pbBatchModeToCore(req.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	to, err := pbStatusToCore(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	var f core.ListTasksFilter
+
+	switch x := req.StatusFilter.(type) {
+	case *taskspb.BulkUpdateStatusRequest_FromStatus:
+		st, err := pbStatusToCore(x.FromStatus)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid from_status")
+		}
+		f.Status = &st
+	}
+
+	switch x := req.PriorityFilter.(type) {
+	case *taskspb.BulkUpdateStatusRequest_Priority:
+		p, err := pbPriorityToCore(x.Priority)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid priority")
+		}
+		f.Priority = &p
+	}
+
+	switch x := req.CategoryFilter.(type) {
+	case *taskspb.BulkUpdateStatusRequest_CategoryId:
+		id := x.CategoryId
+		f.CategoryID = &id
+	case *taskspb.BulkUpdateStatusRequest_WithoutCategory:
+		f.WithoutCategory = x.WithoutCategory
+	}
+	f.IncludeSubcategories = req.GetIncludeSubcategories()
+
+	if req.DueBefore != nil {
+		v, err := timeFromPB(req.GetDueBefore())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid due_before")
+		}
+		f.DueBefore = &v
+	}
+	if req.DueAfter != nil {
+		v, err := timeFromPB(req.GetDueAfter())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid due_after")
+		}
+		f.DueAfter = &v
+	}
+	f.Overdue = req.GetOverdue()
+
+	f.AnyTagIDs = req.GetAnyTagIds()
+	f.AllTagIDs = req.GetAllTagIds()
+
+	res, err := s.service.BulkUpdateStatus(ctx, f, to, mode)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return s.batchToPB(res), nil
+}
+
+// Helpers
+
+func pbBatchModeToCore(m taskspb.BatchMode) (core.BatchMode, error) {
+	switch m {
+	case taskspb.BatchMode_BATCH_MODE_ALL_OR_NOTHING:
+		return core.BatchAllOrNothing, nil
+	case taskspb.BatchMode_BATCH_MODE_BEST_EFFORT:
+		return core.BatchBestEffort, nil
+	default:
+		return 0, fmt.Errorf("invalid mode")
+	}
+}
+
+// batchToPB переводит ошибки элементов в grpc коды так же, как mapErr
+func (s *Server) batchToPB(res []core.BatchResult) *taskspb.BatchTasksResponse {
+	out := &taskspb.BatchTasksResponse{
+		Results: make([]*taskspb.BatchItemResult, 0, len(res)),
+	}
+	for _, r := range res {
+		item := &taskspb.BatchItemResult{TaskId: r.TaskID}
+		if r.Err != nil {
+			st := status.Convert(s.mapErr(r.Err))
+			item.Code = int32(st.Code())
+			item.Error = st.Message()
+			out.Failed++
+		} else {
+			if r.Task.ID != 0 {
+				item.Task = taskToPB(r.Task)
+			}
+			out.Succeeded++
+		}
+		out.Results = append(out.Results, item)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Category tree
+
+func (s *Server) MoveCategory(ctx context.Context, req *taskspb.MoveCategoryRequest) (*taskspb.Category, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	if req.GetParentId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "parent_id cannot be negative")
+	}
+
+	c, err := s.service.MoveCategory(ctx, req.GetId(), req.GetParentId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return categoryToPB(c), nil
+}
+
+func (s *Server) GetCategoryTree(ctx context.Context, req *taskspb.GetCategoryTreeRequest) (*taskspb.GetCategoryTreeResponse, error) {
+	if req == nil || req.GetRootId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid root_id")
+	}
+
+	roots, err := s.service.GetCategoryTree(ctx, req.GetRootId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.GetCategoryTreeResponse{Roots: make([]*taskspb.CategoryNode, 0, len(roots))}
+	for _, n := range roots {
+		resp.Roots = append(resp.Roots, categoryNodeToPB(n))
+	}
+
+	return resp, nil
+}
+
+func (s *Server) MergeCategories(ctx context.Context, req *taskspb.MergeCategoriesRequest) (*taskspb.MergeCategoriesResponse, error) {
+	if req == nil || req.GetTargetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid target_id")
+	}
+	if len(req.GetSourceIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source_ids cannot be empty")
+	}
+
+	m, err := s.service.MergeCategories(ctx, req.GetTargetId(), req.GetSourceIds())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &taskspb.MergeCategoriesResponse{
+		Target:     categoryToPB(m.Target),
+		MovedTasks: m.MovedTasks,
+		MergeId:    m.ID,
+	}, nil
+}
+
+// Helpers
+
+func categoryNodeToPB(n core.CategoryNode) *taskspb.CategoryNode {
+	out := &taskspb.CategoryNode{
+		Category: categoryToPB(n.Category),
+		Children: make([]*taskspb.CategoryNode, 0, len(n.Children)),
+	}
+	for _, c := range n.Children {
+		out.Children = append(out.Children, categoryNodeToPB(c))
+	}
+	return out
+}
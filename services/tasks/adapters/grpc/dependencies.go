@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Dependencies
+
+func (s *Server) AddDependency(ctx context.Context, req *taskspb.AddDependencyRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetTaskId() <= 0 || req.GetBlockedById() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id or blocked_by_id")
+	}
+
+	if err := s.service.AddDependency(ctx, req.GetTaskId(), req.GetBlockedById()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RemoveDependency(ctx context.Context, req *taskspb.RemoveDependencyRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetTaskId() <= 0 || req.GetBlockedById() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id or blocked_by_id")
+	}
+
+	if err := s.service.RemoveDependency(ctx, req.GetTaskId(), req.GetBlockedById()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListDependencies(ctx context.Context, req *taskspb.ListDependenciesRequest) (*taskspb.ListDependenciesResponse, error) {
+	if req == nil || req.GetTaskId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id")
+	}
+
+	deps, err := s.service.ListDependencies(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &taskspb.ListDependenciesResponse{
+		BlockedBy: tasksToPB(deps.BlockedBy),
+		Blocking:  tasksToPB(deps.Blocking),
+	}, nil
+}
+
+// Helpers
+
+func tasksToPB(tasks []core.Task) []*taskspb.Task {
+	out := make([]*taskspb.Task, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, taskToPB(t))
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// History
+
+func (s *Server) ListTaskHistory(ctx context.Context, req *taskspb.ListTaskHistoryRequest) (*taskspb.ListTaskHistoryResponse, error) {
+	if req == nil || req.GetTaskId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id")
+	}
+
+	f := core.ListTaskEventsFilter{TaskID: req.GetTaskId(), Limit: int(req.GetPageSize())}
+	if req.GetPageToken() != "" {
+		var cur core.TaskEventCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListTaskHistory(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListTaskHistoryResponse{Events: make([]*taskspb.TaskEvent, 0, len(page.Events))}
+	for _, e := range page.Events {
+		resp.Events = append(resp.Events, taskEventToPB(e))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+// Helpers
+
+func taskEventToPB(e core.TaskEvent) *taskspb.TaskEvent {
+	out := &taskspb.TaskEvent{
+		Id:        e.ID,
+		TaskId:    e.TaskID,
+		Type:      taskEventTypeToPB(e.Type),
+		Actor:     e.Actor,
+		Changes:   make([]*taskspb.FieldChange, 0, len(e.Changes)),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	for _, c := range e.Changes {
+		out.Changes = append(out.Changes, &taskspb.FieldChange{
+			Field:    c.Field,
+			OldValue: string(c.Old),
+			NewValue: string(c.New),
+		})
+	}
+	return out
+}
+
+func taskEventTypeToPB(t core.TaskEventType) taskspb.TaskEventType {
+	switch t {
+	case core.EventUpdated:
+		return taskspb.TaskEventType_TASK_EVENT_UPDATED
+	case core.EventDeleted:
+		return taskspb.TaskEventType_TASK_EVENT_DELETED
+	case core.EventRestored:
+		return taskspb.TaskEventType_TASK_EVENT_RESTORED
+	case core.EventPurged:
+		return taskspb.TaskEventType_TASK_EVENT_PURGED
+	default:
+		return taskspb.TaskEventType_TASK_EVENT_CREATED
+	}
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// idempotencyHeader - metadata с ключом идемпотентности create-запросов
+const idempotencyHeader = "idempotency-key"
+
+// IdempotencyInterceptor переносит idempotency-key из metadata запроса в контекст core.
+// Поле idempotency_key в самом запросе важнее metadata
+func IdempotencyInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(idempotencyHeader); len(v) > 0 {
+			if key := strings.TrimSpace(v[0]); key != "" {
+				ctx = core.WithIdempotencyKey(ctx, key)
+			}
+		}
+	}
+	return handler(ctx, req)
+}
+
+// withIdempotencyKey кладёт в контекст ключ из поля запроса, если он задан
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key = strings.TrimSpace(key); key != "" {
+		return core.WithIdempotencyKey(ctx, key)
+	}
+	return ctx
+}
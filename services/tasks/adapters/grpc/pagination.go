@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Токены страниц непрозрачны для клиента: base64(json курсора из core)
+
+var errInvalidPageToken = errors.New("invalid page_token")
+
+func encodePageToken(cursor any) string {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string, cursor any) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return errInvalidPageToken
+	}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return errInvalidPageToken
+	}
+	return nil
+}
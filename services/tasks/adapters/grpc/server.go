@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,6 +20,11 @@ import (
 type Server struct {
 	taskspb.UnimplementedCategoriesServiceServer
 	taskspb.UnimplementedTasksServiceServer
+	taskspb.UnimplementedTagsServiceServer
+	taskspb.UnimplementedTrashServiceServer
+	taskspb.UnimplementedWebhooksServiceServer
+	taskspb.UnimplementedUsersServiceServer
+	taskspb.UnimplementedApiKeysServiceServer
 
 	log     *slog.Logger
 	service *core.Service
@@ -43,7 +49,23 @@ func (s *Server) CreateCategory(ctx context.Context, req *taskspb.CreateCategory
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
 
-	c, err := s.service.CreateCategory(ctx, req.GetName())
+	if req.GetParentId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "parent_id cannot be negative")
+	}
+
+	in := core.NewCategory{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Color:       req.GetColor(),
+		Icon:        req.GetIcon(),
+		Position:    req.GetPosition(),
+	}
+	if req.GetParentId() != 0 {
+		id := req.GetParentId()
+		in.ParentID = &id
+	}
+
+	c, err := s.service.CreateCategory(withIdempotencyKey(ctx, req.GetIdempotencyKey()), in)
 	if err != nil {
 		return nil, s.mapErr(err)
 	}
@@ -64,18 +86,53 @@ func (s *Server) GetCategory(ctx context.Context, req *taskspb.GetCategoryReques
 	return categoryToPB(c), nil
 }
 
-func (s *Server) ListCategories(ctx context.Context, _ *taskspb.ListCategoriesRequest) (*taskspb.ListCategoriesResponse, error) {
-	items, err := s.service.ListCategories(ctx)
+func (s *Server) ListCategories(ctx context.Context, req *taskspb.ListCategoriesRequest) (*taskspb.ListCategoriesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	f := core.ListCategoriesFilter{
+		Limit:      int(req.GetPageSize()),
+		WithCounts: req.GetIncludeTaskCounts(),
+	}
+	if req.GetPageToken() != "" {
+		var cur core.CategoryCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListCategories(ctx, f)
 	if err != nil {
 		return nil, s.mapErr(err)
 	}
 
-	out := make([]*taskspb.Category, 0, len(items))
-	for _, c := range items {
+	out := make([]*taskspb.Category, 0, len(page.Categories))
+	for _, c := range page.Categories {
 		out = append(out, categoryToPB(c))
 	}
 
-	return &taskspb.ListCategoriesResponse{Categories: out}, nil
+	resp := &taskspb.ListCategoriesResponse{Categories: out}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+	if page.Counts != nil {
+		resp.TaskCounts = make(map[int64]*taskspb.CategoryTaskCounts, len(page.Categories))
+		// категории без задач тоже получают нулевые счётчики
+		for _, c := range page.Categories {
+			counts := page.Counts[c.ID]
+			resp.TaskCounts[c.ID] = &taskspb.CategoryTaskCounts{
+				Todo:       counts.TODO,
+				InProgress: counts.InProgress,
+				Done:       counts.Done,
+				Archived:   counts.Archived,
+				Total:      counts.Total(),
+			}
+		}
+	}
+
+	return resp, nil
 }
 
 func (s *Server) UpdateCategory(ctx context.Context, req *taskspb.UpdateCategoryRequest) (*taskspb.Category, error) {
@@ -83,7 +140,12 @@ func (s *Server) UpdateCategory(ctx context.Context, req *taskspb.UpdateCategory
 		return nil, status.Error(codes.InvalidArgument, "invalid id")
 	}
 
-	c, err := s.service.UpdateCategory(ctx, req.GetId(), req.GetName())
+	patch, err := categoryPatchFromPB(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	c, err := s.service.PatchCategory(ctx, req.GetId(), patch)
 	if err != nil {
 		return nil, s.mapErr(err)
 	}
@@ -96,7 +158,22 @@ func (s *Server) DeleteCategory(ctx context.Context, req *taskspb.DeleteCategory
 		return nil, status.Error(codes.InvalidArgument, "invalid id")
 	}
 
-	if err := s.service.DeleteCategory(ctx, req.GetId()); err != nil {
+	var d core.CategoryDeletion
+	switch req.GetStrategy() {
+	case taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_ORPHAN:
+		d.Strategy = core.DeleteOrphan
+	case taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_REASSIGN_TO:
+		d.Strategy = core.DeleteReassign
+	case taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_DELETE_TASKS:
+		d.Strategy = core.DeleteTasks
+	case taskspb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_FAIL_IF_NOT_EMPTY:
+		d.Strategy = core.DeleteFailIfNotEmpty
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid strategy")
+	}
+	d.TargetID = req.GetTargetCategoryId()
+
+	if err := s.service.DeleteCategory(ctx, req.GetId(), d); err != nil {
 		return nil, s.mapErr(err)
 	}
 
@@ -109,17 +186,13 @@ func (s *Server) CreateTask(ctx context.Context, req *taskspb.CreateTaskRequest)
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
-	if req.GetCategoryId() < 0 {
-		return nil, status.Error(codes.InvalidArgument, "category_id cannot be negative")
-	}
 
-	var catID *int64
-	if req.GetCategoryId() != 0 {
-		id := req.GetCategoryId()
-		catID = &id
+	in, err := newTaskFromPB(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	t, err := s.service.CreateTask(ctx, catID, req.GetName(), req.GetDescription())
+	t, err := s.service.CreateTask(withIdempotencyKey(ctx, req.GetIdempotencyKey()), in)
 	if err != nil {
 		return nil, s.mapErr(err)
 	}
@@ -127,6 +200,50 @@ func (s *Server) CreateTask(ctx context.Context, req *taskspb.CreateTaskRequest)
 	return taskToPB(t), nil
 }
 
+func newTaskFromPB(req *taskspb.CreateTaskRequest) (core.NewTask, error) {
+	if req.GetCategoryId() < 0 {
+		return core.NewTask{}, fmt.Errorf("category_id cannot be negative")
+	}
+	if req.GetParentId() < 0 {
+		return core.NewTask{}, fmt.Errorf("parent_id cannot be negative")
+	}
+	if req.GetAssigneeId() < 0 {
+		return core.NewTask{}, fmt.Errorf("assignee_id cannot be negative")
+	}
+
+	prio, err := pbPriorityToCore(req.GetPriority())
+	if err != nil {
+		return core.NewTask{}, fmt.Errorf("invalid priority")
+	}
+
+	in := core.NewTask{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Priority:    prio,
+	}
+	if req.GetCategoryId() != 0 {
+		id := req.GetCategoryId()
+		in.CategoryID = &id
+	}
+	if req.GetParentId() != 0 {
+		id := req.GetParentId()
+		in.ParentID = &id
+	}
+	if req.GetAssigneeId() != 0 {
+		id := req.GetAssigneeId()
+		in.AssigneeID = &id
+	}
+	if req.DueAt != nil {
+		due, err := timeFromPB(req.GetDueAt())
+		if err != nil {
+			return core.NewTask{}, fmt.Errorf("invalid due_at")
+		}
+		in.DueAt = &due
+	}
+
+	return in, nil
+}
+
 func (s *Server) GetTask(ctx context.Context, req *taskspb.GetTaskRequest) (*taskspb.Task, error) {
 	if req == nil || req.GetId() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "invalid id")
@@ -157,6 +274,16 @@ func (s *Server) ListTask(ctx context.Context, req *taskspb.ListTaskRequest) (*t
 		f.Status = &st
 	}
 
+	// priority_filter oneof
+	switch x := req.PriorityFilter.(type) {
+	case *taskspb.ListTaskRequest_Priority:
+		p, err := pbPriorityToCore(x.Priority)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid priority")
+		}
+		f.Priority = &p
+	}
+
 	// category_filter oneof
 	switch x := req.CategoryFilter.(type) {
 	case *taskspb.ListTaskRequest_CategoryId:
@@ -165,21 +292,89 @@ func (s *Server) ListTask(ctx context.Context, req *taskspb.ListTaskRequest) (*t
 	case *taskspb.ListTaskRequest_WithoutCategory:
 		f.WithoutCategory = x.WithoutCategory
 	}
+	f.IncludeSubcategories = req.GetIncludeSubcategories()
+
+	// assignee_filter oneof
+	switch x := req.AssigneeFilter.(type) {
+	case *taskspb.ListTaskRequest_AssigneeId:
+		id := x.AssigneeId
+		f.AssigneeID = &id
+	case *taskspb.ListTaskRequest_AssignedToMe:
+		f.AssignedToMe = x.AssignedToMe
+	case *taskspb.ListTaskRequest_Unassigned:
+		f.Unassigned = x.Unassigned
+	}
+	if req.GetCreatorId() != 0 {
+		id := req.GetCreatorId()
+		f.CreatorID = &id
+	}
+
+	if req.DueBefore != nil {
+		v, err := timeFromPB(req.GetDueBefore())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid due_before")
+		}
+		f.DueBefore = &v
+	}
+	if req.DueAfter != nil {
+		v, err := timeFromPB(req.GetDueAfter())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid due_after")
+		}
+		f.DueAfter = &v
+	}
+	f.Overdue = req.GetOverdue()
+
+	f.AnyTagIDs = req.GetAnyTagIds()
+	f.AllTagIDs = req.GetAllTagIds()
+
+	f.Query = req.GetQuery()
+	f.Highlight = req.GetHighlight()
+
+	switch {
+	case req.Sort != nil:
+		sort, err := pbSortToCore(req.GetSort())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid sort")
+		}
+		f.Sort = sort
+	case strings.TrimSpace(f.Query) != "":
+		// с query по умолчанию ранжируем по релевантности
+		f.Sort = core.SortRelevance
+	}
+
+	if req.GetPageToken() != "" {
+		var cur core.TaskCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+	f.WithTotal = req.GetIncludeTotalCount()
 
 	f.Limit = int(req.GetLimit())
 	f.Offset = int(req.GetOffset())
 
-	items, err := s.service.ListTasks(ctx, f)
+	page, err := s.service.ListTasks(ctx, f)
 	if err != nil {
 		return nil, s.mapErr(err)
 	}
 
-	out := make([]*taskspb.Task, 0, len(items))
-	for _, t := range items {
+	out := make([]*taskspb.Task, 0, len(page.Tasks))
+	for _, t := range page.Tasks {
 		out = append(out, taskToPB(t))
 	}
 
-	return &taskspb.ListTaskResponse{Tasks: out}, nil
+	resp := &taskspb.ListTaskResponse{
+		Tasks:      out,
+		TotalCount: page.Total,
+		Highlights: page.Highlights,
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
 }
 
 func (s *Server) UpdateTask(ctx context.Context, req *taskspb.UpdateTaskRequest) (*taskspb.Task, error) {
@@ -205,7 +400,11 @@ func (s *Server) DeleteTask(ctx context.Context, req *taskspb.DeleteTaskRequest)
 		return nil, status.Error(codes.InvalidArgument, "invalid id")
 	}
 
-	if err := s.service.DeleteTask(ctx, req.GetId()); err != nil {
+	if req.GetExpectedVersion() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version cannot be negative")
+	}
+
+	if err := s.service.DeleteTask(ctx, req.GetId(), req.GetExpectedVersion()); err != nil {
 		return nil, s.mapErr(err)
 	}
 
@@ -215,11 +414,25 @@ func (s *Server) DeleteTask(ctx context.Context, req *taskspb.DeleteTaskRequest)
 // Helpers
 
 func categoryToPB(c core.Category) *taskspb.Category {
-	return &taskspb.Category{
-		Id:        c.ID,
-		Name:      c.Name,
-		CreatedAt: timestamppb.New(c.CreatedAt),
+	var parentID int64
+	if c.ParentID != nil {
+		parentID = *c.ParentID
+	}
+
+	out := &taskspb.Category{
+		Id:          c.ID,
+		ParentId:    parentID, // 0 => корневая
+		Name:        c.Name,
+		Description: c.Description,
+		Color:       c.Color,
+		Icon:        c.Icon,
+		Position:    c.Position,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+	}
+	if c.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*c.DeletedAt)
 	}
+	return out
 }
 
 func taskToPB(t core.Task) *taskspb.Task {
@@ -228,15 +441,51 @@ func taskToPB(t core.Task) *taskspb.Task {
 		catID = *t.CategoryID
 	}
 
-	return &taskspb.Task{
+	var parentID int64
+	if t.ParentID != nil {
+		parentID = *t.ParentID
+	}
+
+	out := &taskspb.Task{
 		Id:          t.ID,
-		CategoryId:  catID, // 0 => без категории
+		CategoryId:  catID,    // 0 => без категории
+		ParentId:    parentID, // 0 => верхний уровень
 		Name:        t.Name,
 		Description: t.Description,
 		Status:      coreStatusToPB(t.Status),
+		Priority:    corePriorityToPB(t.Priority),
 		CreatedAt:   timestamppb.New(t.CreatedAt),
 		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+		Tags:        tagsToPB(t.Tags),
+		Version:     t.Version,
+	}
+	if t.CreatorID != nil {
+		out.CreatorId = *t.CreatorID
 	}
+	if t.AssigneeID != nil {
+		out.AssigneeId = *t.AssigneeID
+	}
+	if t.DueAt != nil {
+		out.DueAt = timestamppb.New(*t.DueAt)
+	}
+	if t.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*t.CompletedAt)
+	}
+	if t.ArchivedAt != nil {
+		out.ArchivedAt = timestamppb.New(*t.ArchivedAt)
+	}
+	if t.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*t.DeletedAt)
+	}
+
+	return out
+}
+
+func timeFromPB(ts *timestamppb.Timestamp) (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, err
+	}
+	return ts.AsTime(), nil
 }
 
 func pbStatusToCore(st taskspb.TaskStatus) (core.TaskStatus, error) {
@@ -254,8 +503,83 @@ func pbStatusToCore(st taskspb.TaskStatus) (core.TaskStatus, error) {
 	}
 }
 
+func pbPriorityToCore(p taskspb.TaskPriority) (core.TaskPriority, error) {
+	switch p {
+	case taskspb.TaskPriority_TASK_PRIORITY_LOW:
+		return core.PriorityLow, nil
+	case taskspb.TaskPriority_TASK_PRIORITY_MEDIUM:
+		return core.PriorityMedium, nil
+	case taskspb.TaskPriority_TASK_PRIORITY_HIGH:
+		return core.PriorityHigh, nil
+	case taskspb.TaskPriority_TASK_PRIORITY_URGENT:
+		return core.PriorityUrgent, nil
+	default:
+		return core.PriorityLow, errors.New("unknown priority")
+	}
+}
+
+func pbSortToCore(s taskspb.TaskSort) (core.TaskSort, error) {
+	switch s {
+	case taskspb.TaskSort_TASK_SORT_CREATED_AT_DESC:
+		return core.SortCreatedAtDesc, nil
+	case taskspb.TaskSort_TASK_SORT_PRIORITY_DESC:
+		return core.SortPriorityDesc, nil
+	case taskspb.TaskSort_TASK_SORT_RELEVANCE:
+		return core.SortRelevance, nil
+	default:
+		return core.SortCreatedAtDesc, errors.New("unknown sort")
+	}
+}
+
+func categoryPatchFromPB(req *taskspb.UpdateCategoryRequest) (core.CategoryPatch, error) {
+	var p core.CategoryPatch
+
+	useMask := req.GetUpdateMask() != nil && len(req.GetUpdateMask().GetPaths()) > 0
+	if useMask {
+		for _, path := range req.GetUpdateMask().GetPaths() {
+			switch path {
+			case "name":
+				if req.Name == nil {
+					return p, fmt.Errorf("update_mask includes name but name is not set")
+				}
+				p.Name = req.Name
+
+			case "description":
+				// description не задан => очистить
+				v := req.GetDescription()
+				p.Description = &v
+
+			case "color":
+				v := req.GetColor()
+				p.Color = &v
+
+			case "icon":
+				v := req.GetIcon()
+				p.Icon = &v
+
+			case "position":
+				v := req.GetPosition()
+				p.Position = &v
+
+			default:
+				return p, fmt.Errorf("unknown field in update_mask: %s", path)
+			}
+		}
+	} else {
+		// infer patch by presence of optional fields
+		p.Name = req.Name
+		p.Description = req.Description
+		p.Color = req.Color
+		p.Icon = req.Icon
+		p.Position = req.Position
+	}
+
+	return p, nil
+}
+
 func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
-	var p core.TaskPatch
+	// expected_version - условие записи, а не поле задачи, поэтому в маску не входит
+	p := core.TaskPatch{ExpectedVersion: req.GetExpectedVersion()}
 
 	useMask := req.GetUpdateMask() != nil && len(req.GetUpdateMask().GetPaths()) > 0
 	if useMask {
@@ -268,6 +592,13 @@ func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
 				v := req.GetCategoryId()
 				p.CategoryID = &v
 
+			case "parent_id":
+				if req.ParentId == nil {
+					return p, fmt.Errorf("update_mask includes parent_id but parent_id is not set")
+				}
+				v := req.GetParentId()
+				p.ParentID = &v
+
 			case "name":
 				if req.Name == nil {
 					return p, fmt.Errorf("update_mask includes name but name is not set")
@@ -292,6 +623,28 @@ func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
 				}
 				p.Status = &st
 
+			case "priority":
+				if req.Priority == nil {
+					return p, fmt.Errorf("update_mask includes priority but priority is not set")
+				}
+				prio, err := pbPriorityToCore(req.GetPriority())
+				if err != nil {
+					return p, fmt.Errorf("invalid priority")
+				}
+				p.Priority = &prio
+
+			case "due_at":
+				// due_at не задан => снять срок
+				var due time.Time
+				if req.DueAt != nil {
+					v, err := timeFromPB(req.GetDueAt())
+					if err != nil {
+						return p, fmt.Errorf("invalid due_at")
+					}
+					due = v
+				}
+				p.DueAt = &due
+
 			default:
 				return p, fmt.Errorf("unknown field in update_mask: %s", path)
 			}
@@ -302,6 +655,10 @@ func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
 			v := req.GetCategoryId()
 			p.CategoryID = &v
 		}
+		if req.ParentId != nil {
+			v := req.GetParentId()
+			p.ParentID = &v
+		}
 		if req.Name != nil {
 			v := req.GetName()
 			p.Name = &v
@@ -317,10 +674,24 @@ func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
 			}
 			p.Status = &st
 		}
+		if req.Priority != nil {
+			prio, err := pbPriorityToCore(req.GetPriority())
+			if err != nil {
+				return p, fmt.Errorf("invalid priority")
+			}
+			p.Priority = &prio
+		}
+		if req.DueAt != nil {
+			due, err := timeFromPB(req.GetDueAt())
+			if err != nil {
+				return p, fmt.Errorf("invalid due_at")
+			}
+			p.DueAt = &due
+		}
 	}
 
 	// запретим пустой patch
-	if p.CategoryID == nil && p.Name == nil && p.Description == nil && p.Status == nil {
+	if p.CategoryID == nil && p.Name == nil && p.Description == nil && p.Status == nil && p.Priority == nil && p.DueAt == nil && p.ParentID == nil {
 		return p, fmt.Errorf("no fields to update")
 	}
 
@@ -331,6 +702,9 @@ func taskPatchFromPB(req *taskspb.UpdateTaskRequest) (core.TaskPatch, error) {
 	if p.CategoryID != nil && *p.CategoryID < 0 {
 		return p, fmt.Errorf("category_id cannot be negative")
 	}
+	if p.ParentID != nil && *p.ParentID < 0 {
+		return p, fmt.Errorf("parent_id cannot be negative")
+	}
 
 	return p, nil
 }
@@ -350,6 +724,21 @@ func coreStatusToPB(st core.TaskStatus) taskspb.TaskStatus {
 	}
 }
 
+func corePriorityToPB(p core.TaskPriority) taskspb.TaskPriority {
+	switch p {
+	case core.PriorityLow:
+		return taskspb.TaskPriority_TASK_PRIORITY_LOW
+	case core.PriorityMedium:
+		return taskspb.TaskPriority_TASK_PRIORITY_MEDIUM
+	case core.PriorityHigh:
+		return taskspb.TaskPriority_TASK_PRIORITY_HIGH
+	case core.PriorityUrgent:
+		return taskspb.TaskPriority_TASK_PRIORITY_URGENT
+	default:
+		return taskspb.TaskPriority_TASK_PRIORITY_LOW
+	}
+}
+
 func (s *Server) mapErr(err error) error {
 	switch {
 	// categories
@@ -359,6 +748,11 @@ func (s *Server) mapErr(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, core.ErrCategoryAlreadyExists):
 		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, core.ErrCategoryNotEmpty),
+		errors.Is(err, core.ErrCategoryCycle),
+		errors.Is(err, core.ErrCategoryDeleted),
+		errors.Is(err, core.ErrLastCategoryOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
 
 	// tasks
 	case errors.Is(err, core.ErrTaskInvalidArgs):
@@ -367,6 +761,67 @@ func (s *Server) mapErr(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, core.ErrTaskAlreadyExists):
 		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, core.ErrTaskCycle),
+		errors.Is(err, core.ErrTaskHasOpenSubtasks),
+		errors.Is(err, core.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, core.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+
+	// dependencies
+	case errors.Is(err, core.ErrDependencyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, core.ErrDependencyCycle),
+		errors.Is(err, core.ErrTaskBlocked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+
+	// users
+	case errors.Is(err, core.ErrUserInvalidArgs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, core.ErrUserAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+
+	// auth
+	case errors.Is(err, core.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, core.ErrAPIKeyInvalidArgs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrAPIKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+
+	// access
+	case errors.Is(err, core.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, core.ErrCategoryMemberNotFound):
+		return status.Error(codes.NotFound, err.Error())
+
+	// tags
+	case errors.Is(err, core.ErrTagInvalidArgs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrTagNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, core.ErrTagAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+
+	// idempotency
+	case errors.Is(err, core.ErrIdempotencyKeyInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrIdempotencyKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+
+	// webhooks
+	case errors.Is(err, core.ErrWebhookInvalidArgs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrWebhookNotFound):
+		return status.Error(codes.NotFound, err.Error())
+
+	// batch
+	case errors.Is(err, core.ErrBatchAborted):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, core.ErrBatchTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
 
 	default:
 		s.log.Error("internal error", "error", err)
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Subtasks
+
+func (s *Server) ListSubtasks(ctx context.Context, req *taskspb.ListSubtasksRequest) (*taskspb.ListSubtasksResponse, error) {
+	if req == nil || req.GetParentId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid parent_id")
+	}
+
+	var after *core.TaskCursor
+	if req.GetPageToken() != "" {
+		var cur core.TaskCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		after = &cur
+	}
+
+	page, err := s.service.ListSubtasks(ctx, req.GetParentId(), int(req.GetLimit()), after)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	out := make([]*taskspb.Task, 0, len(page.Tasks))
+	for _, t := range page.Tasks {
+		out = append(out, taskToPB(t))
+	}
+
+	resp := &taskspb.ListSubtasksResponse{Tasks: out}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetTaskTree(ctx context.Context, req *taskspb.GetTaskTreeRequest) (*taskspb.TaskNode, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	tree, err := s.service.GetTaskTree(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskNodeToPB(tree), nil
+}
+
+// Helpers
+
+func taskNodeToPB(n core.TaskNode) *taskspb.TaskNode {
+	out := &taskspb.TaskNode{
+		Task:     taskToPB(n.Task),
+		Children: make([]*taskspb.TaskNode, 0, len(n.Children)),
+	}
+	for _, c := range n.Children {
+		out.Children = append(out.Children, taskNodeToPB(c))
+	}
+	return out
+}
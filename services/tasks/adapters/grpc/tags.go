@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Tags
+
+func (s *Server) CreateTag(ctx context.Context, req *taskspb.CreateTagRequest) (*taskspb.Tag, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	t, err := s.service.CreateTag(ctx, req.GetName())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return tagToPB(t), nil
+}
+
+func (s *Server) GetTag(ctx context.Context, req *taskspb.GetTagRequest) (*taskspb.Tag, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	t, err := s.service.GetTag(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return tagToPB(t), nil
+}
+
+func (s *Server) ListTags(ctx context.Context, req *taskspb.ListTagsRequest) (*taskspb.ListTagsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	f := core.ListTagsFilter{Limit: int(req.GetPageSize())}
+	if req.GetPageToken() != "" {
+		var cur core.TagCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListTags(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListTagsResponse{Tags: tagsToPB(page.Tags)}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) RenameTag(ctx context.Context, req *taskspb.RenameTagRequest) (*taskspb.Tag, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	t, err := s.service.RenameTag(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return tagToPB(t), nil
+}
+
+func (s *Server) DeleteTag(ctx context.Context, req *taskspb.DeleteTagRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	if err := s.service.DeleteTag(ctx, req.GetId()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) MergeTags(ctx context.Context, req *taskspb.MergeTagsRequest) (*taskspb.Tag, error) {
+	if req == nil || req.GetTargetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid target_id")
+	}
+	if len(req.GetSourceIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source_ids cannot be empty")
+	}
+
+	t, err := s.service.MergeTags(ctx, req.GetTargetId(), req.GetSourceIds())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return tagToPB(t), nil
+}
+
+// Task tags
+
+func (s *Server) AddTags(ctx context.Context, req *taskspb.AddTagsRequest) (*taskspb.Task, error) {
+	if req == nil || req.GetTaskId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id")
+	}
+	if len(req.GetTagIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tag_ids cannot be empty")
+	}
+
+	t, err := s.service.AddTags(ctx, req.GetTaskId(), req.GetTagIds())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskToPB(t), nil
+}
+
+func (s *Server) RemoveTags(ctx context.Context, req *taskspb.RemoveTagsRequest) (*taskspb.Task, error) {
+	if req == nil || req.GetTaskId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid task_id")
+	}
+	if len(req.GetTagIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tag_ids cannot be empty")
+	}
+
+	t, err := s.service.RemoveTags(ctx, req.GetTaskId(), req.GetTagIds())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskToPB(t), nil
+}
+
+// Helpers
+
+func tagToPB(t core.Tag) *taskspb.Tag {
+	return &taskspb.Tag{
+		Id:        t.ID,
+		Name:      t.Name,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
+
+func tagsToPB(tags []core.Tag) []*taskspb.Tag {
+	out := make([]*taskspb.Tag, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, tagToPB(t))
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Trash
+
+func (s *Server) RestoreTask(ctx context.Context, req *taskspb.RestoreTaskRequest) (*taskspb.Task, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	t, err := s.service.RestoreTask(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskToPB(t), nil
+}
+
+func (s *Server) RestoreCategory(ctx context.Context, req *taskspb.RestoreCategoryRequest) (*taskspb.Category, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	c, err := s.service.RestoreCategory(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return categoryToPB(c), nil
+}
+
+func (s *Server) ListDeleted(ctx context.Context, req *taskspb.ListDeletedRequest) (*taskspb.ListDeletedResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	var f core.ListDeletedFilter
+	switch req.GetKind() {
+	case taskspb.TrashKind_TRASH_KIND_TASKS:
+		f.Kind = core.TrashTasks
+	case taskspb.TrashKind_TRASH_KIND_CATEGORIES:
+		f.Kind = core.TrashCategories
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid kind")
+	}
+
+	f.Limit = int(req.GetPageSize())
+	if req.GetPageToken() != "" {
+		var cur core.DeletedCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListDeleted(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListDeletedResponse{
+		Tasks:      tasksToPB(page.Tasks),
+		Categories: make([]*taskspb.Category, 0, len(page.Categories)),
+	}
+	for _, c := range page.Categories {
+		resp.Categories = append(resp.Categories, categoryToPB(c))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) PurgeDeleted(ctx context.Context, req *taskspb.PurgeDeletedRequest) (*taskspb.PurgeDeletedResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	var before time.Time
+	if req.DeletedBefore != nil {
+		if err := req.GetDeletedBefore().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid deleted_before")
+		}
+		before = req.GetDeletedBefore().AsTime()
+	}
+
+	res, err := s.service.PurgeDeleted(ctx, before)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &taskspb.PurgeDeletedResponse{Tasks: res.Tasks, Categories: res.Categories}, nil
+}
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Users
+
+func (s *Server) CreateUser(ctx context.Context, req *taskspb.CreateUserRequest) (*taskspb.User, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	u, err := s.service.CreateUser(ctx, core.NewUser{Name: req.GetName(), Email: req.GetEmail()})
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return userToPB(u), nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *taskspb.GetUserRequest) (*taskspb.User, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	u, err := s.service.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return userToPB(u), nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *taskspb.ListUsersRequest) (*taskspb.ListUsersResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	f := core.ListUsersFilter{Limit: int(req.GetPageSize())}
+	if req.GetPageToken() != "" {
+		var cur core.UserCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListUsers(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListUsersResponse{Users: make([]*taskspb.User, 0, len(page.Users))}
+	for _, u := range page.Users {
+		resp.Users = append(resp.Users, userToPB(u))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, req *taskspb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	if err := s.service.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Assignees
+
+func (s *Server) AssignTask(ctx context.Context, req *taskspb.AssignTaskRequest) (*taskspb.Task, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version cannot be negative")
+	}
+
+	t, err := s.service.AssignTask(ctx, req.GetId(), req.GetUserId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskToPB(t), nil
+}
+
+func (s *Server) UnassignTask(ctx context.Context, req *taskspb.UnassignTaskRequest) (*taskspb.Task, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version cannot be negative")
+	}
+
+	t, err := s.service.UnassignTask(ctx, req.GetId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return taskToPB(t), nil
+}
+
+// Helpers
+
+func userToPB(u core.User) *taskspb.User {
+	return &taskspb.User{
+		Id:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+	}
+}
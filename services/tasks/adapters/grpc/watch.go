@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Watch
+
+func (s *Server) WatchTasks(req *taskspb.WatchTasksRequest, stream grpc.ServerStreamingServer[taskspb.TaskChange]) error {
+	if req == nil || req.GetAfterEventId() < 0 {
+		return status.Error(codes.InvalidArgument, "invalid after_event_id")
+	}
+
+	f := core.WatchTasksFilter{AfterEventID: req.GetAfterEventId()}
+	if req.CategoryId != nil {
+		if req.GetCategoryId() <= 0 {
+			return status.Error(codes.InvalidArgument, "invalid category_id")
+		}
+		id := req.GetCategoryId()
+		f.CategoryID = &id
+	}
+	if req.Status != nil {
+		st, err := pbStatusToCore(req.GetStatus())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid status")
+		}
+		f.Status = &st
+	}
+
+	ctx := stream.Context()
+	err := s.service.WatchTasks(ctx, f, func(c core.TaskChange) error {
+		out := &taskspb.TaskChange{Event: taskEventToPB(c.Event)}
+		if c.Task != nil {
+			out.Task = taskToPB(*c.Task)
+		}
+		return stream.Send(out)
+	})
+	if ctx.Err() != nil {
+		// клиент отключился или сервер останавливается
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// ошибка stream.Send уже в виде grpc статуса
+			return err
+		}
+		return s.mapErr(err)
+	}
+	return nil
+}
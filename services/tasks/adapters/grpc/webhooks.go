@@ -0,0 +1,196 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/core"
+)
+
+// Webhooks
+
+func (s *Server) CreateWebhook(ctx context.Context, req *taskspb.CreateWebhookRequest) (*taskspb.CreateWebhookResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.GetCategoryId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "category_id cannot be negative")
+	}
+
+	in := core.NewWebhook{
+		URL:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+	}
+	if req.GetCategoryId() != 0 {
+		id := req.GetCategoryId()
+		in.CategoryID = &id
+	}
+
+	w, err := s.service.CreateWebhook(ctx, in)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &taskspb.CreateWebhookResponse{Webhook: webhookToPB(w), Secret: w.Secret}, nil
+}
+
+func (s *Server) GetWebhook(ctx context.Context, req *taskspb.GetWebhookRequest) (*taskspb.Webhook, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	w, err := s.service.GetWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return webhookToPB(w), nil
+}
+
+func (s *Server) ListWebhooks(ctx context.Context, req *taskspb.ListWebhooksRequest) (*taskspb.ListWebhooksResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	f := core.ListWebhooksFilter{Limit: int(req.GetPageSize())}
+	if req.GetPageToken() != "" {
+		var cur core.WebhookCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListWebhooks(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListWebhooksResponse{Webhooks: make([]*taskspb.Webhook, 0, len(page.Webhooks))}
+	for _, w := range page.Webhooks {
+		resp.Webhooks = append(resp.Webhooks, webhookToPB(w))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, req *taskspb.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	if req == nil || req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	if err := s.service.DeleteWebhook(ctx, req.GetId()); err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListDeliveries(ctx context.Context, req *taskspb.ListDeliveriesRequest) (*taskspb.ListDeliveriesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.GetWebhookId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id cannot be negative")
+	}
+
+	f := core.ListDeliveriesFilter{WebhookID: req.GetWebhookId(), Limit: int(req.GetPageSize())}
+	if req.Status != nil {
+		st, err := deliveryStatusFromPB(req.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		f.Status = &st
+	}
+	if req.GetPageToken() != "" {
+		var cur core.DeliveryCursor
+		if err := decodePageToken(req.GetPageToken(), &cur); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &cur
+	}
+
+	page, err := s.service.ListDeliveries(ctx, f)
+	if err != nil {
+		return nil, s.mapErr(err)
+	}
+
+	resp := &taskspb.ListDeliveriesResponse{Deliveries: make([]*taskspb.WebhookDelivery, 0, len(page.Deliveries))}
+	for _, d := range page.Deliveries {
+		resp.Deliveries = append(resp.Deliveries, deliveryToPB(d))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = encodePageToken(page.Next)
+	}
+
+	return resp, nil
+}
+
+// Helpers
+
+// webhookToPB не отдаёт секрет, он возвращается только в CreateWebhookResponse
+func webhookToPB(w core.Webhook) *taskspb.Webhook {
+	out := &taskspb.Webhook{
+		Id:         w.ID,
+		Url:        w.URL,
+		EventTypes: w.EventTypes,
+		CreatedAt:  timestamppb.New(w.CreatedAt),
+	}
+	if w.CategoryID != nil {
+		out.CategoryId = *w.CategoryID
+	}
+	return out
+}
+
+func deliveryToPB(d core.WebhookDelivery) *taskspb.WebhookDelivery {
+	out := &taskspb.WebhookDelivery{
+		Id:            d.ID,
+		WebhookId:     d.WebhookID,
+		EventId:       d.EventID,
+		EventType:     d.EventType,
+		Status:        deliveryStatusToPB(d.Status),
+		Attempts:      int32(d.Attempts),
+		ResponseCode:  int32(d.ResponseCode),
+		LastError:     d.LastError,
+		Payload:       string(d.Payload),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+	}
+	if d.DeliveredAt != nil {
+		out.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	return out
+}
+
+func deliveryStatusToPB(st core.DeliveryStatus) taskspb.DeliveryStatus {
+	switch st {
+	case core.DeliveryDelivered:
+		return taskspb.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+	case core.DeliveryDead:
+		return taskspb.DeliveryStatus_DELIVERY_STATUS_DEAD
+	default:
+		return taskspb.DeliveryStatus_DELIVERY_STATUS_PENDING
+	}
+}
+
+func deliveryStatusFromPB(st taskspb.DeliveryStatus) (core.DeliveryStatus, error) {
+	switch st {
+	case taskspb.DeliveryStatus_DELIVERY_STATUS_PENDING:
+		return core.DeliveryPending, nil
+	case taskspb.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return core.DeliveryDelivered, nil
+	case taskspb.DeliveryStatus_DELIVERY_STATUS_DEAD:
+		return core.DeliveryDead, nil
+	default:
+		return 0, status.Error(codes.InvalidArgument, "invalid status")
+	}
+}
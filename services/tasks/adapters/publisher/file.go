@@ -0,0 +1,50 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// File дописывает события в файл, по одному JSON на строку
+type File struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func NewFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox file: %w", err)
+	}
+	return &File{f: f}, nil
+}
+
+// Publish возвращает управление только после fsync, иначе событие могло бы
+// считаться отправленным и потеряться при падении
+func (p *File) Publish(_ context.Context, e core.OutboxEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal outbox event: %w", err)
+	}
+	b = append(b, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.f.Write(b); err != nil {
+		return fmt.Errorf("write outbox event: %w", err)
+	}
+	if err := p.f.Sync(); err != nil {
+		return fmt.Errorf("sync outbox file: %w", err)
+	}
+	return nil
+}
+
+func (p *File) Close() error {
+	return p.f.Close()
+}
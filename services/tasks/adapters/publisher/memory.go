@@ -0,0 +1,48 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Memory хранит опубликованные события в памяти, для тестов и локального запуска
+type Memory struct {
+	mu     sync.Mutex
+	events []core.OutboxEvent
+
+	// Fail, если задан, вызывается перед публикацией; ошибка => событие не принято
+	Fail func(e core.OutboxEvent) error
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (p *Memory) Publish(_ context.Context, e core.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Fail != nil {
+		if err := p.Fail(e); err != nil {
+			return err
+		}
+	}
+	p.events = append(p.events, e)
+	return nil
+}
+
+// Events возвращает копию принятых событий в порядке публикации, с повторами
+func (p *Memory) Events() []core.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]core.OutboxEvent, len(p.events))
+	copy(out, p.events)
+	return out
+}
+
+func (p *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"fmt"
+	"io"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Виды publisher в конфиге
+const (
+	KindNone   = ""
+	KindFile   = "file"
+	KindMemory = "memory"
+)
+
+// Publisher - core.Publisher, который держит ресурс до Close
+type Publisher interface {
+	core.Publisher
+	io.Closer
+}
+
+// New создаёт publisher по виду из конфига; KindNone => nil, relay не запускается.
+// Адаптеры NATS и Kafka добавляются здесь же
+func New(kind, path string) (Publisher, error) {
+	switch kind {
+	case KindNone:
+		return nil, nil
+	case KindFile:
+		if path == "" {
+			return nil, fmt.Errorf("outbox file path is required for %q publisher", kind)
+		}
+		return NewFile(path)
+	case KindMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", kind)
+	}
+}
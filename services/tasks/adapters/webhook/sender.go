@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// Заголовки запроса вебхука
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Event-Id" // одинаковый у повторов, для дедупликации
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp" // unix-время отправки в секундах
+	HeaderSignature = "X-Webhook-Signature" // sha256=<hex HMAC-SHA256>
+)
+
+const signaturePrefix = "sha256="
+
+// ErrForbiddenAddress - адрес получателя во внутренней сети
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// Sender отправляет доставки POST-запросом с HMAC-подписью. URL вебхука задаёт
+// клиент API, поэтому адрес проверяется при соединении, уже после DNS: запросы
+// во внутреннюю сеть и на сам сервис отклоняются, редиректы не выполняются
+type Sender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+type SenderOption func(*Sender)
+
+// AllowPrivateNetworks разрешает отправку на loopback и внутренние адреса,
+// для локального запуска, когда получатель в той же сети
+func AllowPrivateNetworks() SenderOption {
+	return func(s *Sender) {
+		s.allowPrivate = true
+	}
+}
+
+// NewSender создаёт Sender; timeout ограничивает одну попытку целиком
+func NewSender(timeout time.Duration, opts ...SenderOption) *Sender {
+	s := &Sender{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !s.allowPrivate {
+		dialer.Control = checkAddress
+	}
+	transport := &http.Transport{
+		// прокси из окружения обошёл бы проверку адреса
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   timeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	s.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// редирект мог бы увести запрос на другой адрес; 3xx - неудачная попытка
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return s
+}
+
+func (s *Sender) Send(ctx context.Context, d core.PendingDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tasks-service-webhooks")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderEventID, strconv.FormatInt(d.EventID, 10))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Secret, ts, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// дочитываем тело, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
+
+// checkAddress - Control dialer: address уже разрешён в ip:port
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	if !publicAddr(ap.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ap.Addr())
+	}
+	return nil
+}
+
+// sharedAddrSpace - 100.64.0.0/10, адреса за NAT провайдера (RFC 6598)
+var sharedAddrSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr отсекает loopback, link-local, multicast, unspecified, частные сети и shared address space
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!sharedAddrSpace.Contains(ip)
+}
+
+// Sign возвращает значение X-Webhook-Signature: HMAC-SHA256 от "<timestamp>.<body>".
+// Метка времени в подписи не даёт переотправить старый запрос с новой меткой
+func Sign(secret string, ts int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись запроса на стороне получателя; запросы старше
+// tolerance отклоняются, tolerance <= 0 => возраст не проверяется
+func Verify(secret string, r *http.Request, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", HeaderTimestamp)
+	}
+	if tolerance > 0 {
+		if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+			return errors.New("webhook timestamp outside tolerance")
+		}
+	}
+
+	want := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(want), []byte(r.Header.Get(HeaderSignature))) {
+		return errors.New("webhook signature mismatch")
+	}
+	return nil
+}
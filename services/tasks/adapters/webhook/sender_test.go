@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"task-manager-microservice/tasks/core"
+)
+
+func delivery(url string) core.PendingDelivery {
+	return core.PendingDelivery{
+		WebhookDelivery: core.WebhookDelivery{
+			ID:        5,
+			EventID:   42,
+			EventType: core.OutboxTaskCreated,
+			Payload:   json.RawMessage(`{"task_id":7}`),
+		},
+		URL:    url,
+		Secret: "s3cret",
+	}
+}
+
+func TestSenderSignsRequest(t *testing.T) {
+	var verifyErr error
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		header = r.Header
+		verifyErr = Verify("s3cret", r, body, time.Minute)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	code, err := NewSender(time.Second, AllowPrivateNetworks()).Send(context.Background(), delivery(srv.URL))
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v", code, err)
+	}
+	if verifyErr != nil {
+		t.Fatalf("Verify: %v", verifyErr)
+	}
+	if header.Get(HeaderEvent) != core.OutboxTaskCreated || header.Get(HeaderEventID) != "42" || header.Get(HeaderDelivery) != "5" {
+		t.Errorf("headers %v", header)
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	body := []byte(`{"task_id":7}`)
+	ts := time.Now().Unix()
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		ts     int64
+	}{
+		{"wrong secret", "other", body, ts},
+		{"changed body", "s3cret", []byte(`{"task_id":8}`), ts},
+		{"old timestamp", "s3cret", body, ts - 3600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set(HeaderTimestamp, strconv.FormatInt(tt.ts, 10))
+			r.Header.Set(HeaderSignature, Sign("s3cret", tt.ts, body))
+
+			if err := Verify(tt.secret, r, tt.body, time.Minute); err == nil {
+				t.Fatal("Verify accepted the request")
+			}
+		})
+	}
+}
+
+func TestSenderRejectsPrivateAddress(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer srv.Close()
+
+	_, err := NewSender(time.Second).Send(context.Background(), delivery(srv.URL))
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Send error = %v, want ErrForbiddenAddress", err)
+	}
+	if hit {
+		t.Fatal("request reached a loopback address")
+	}
+}
+
+func TestSenderDoesNotFollowRedirects(t *testing.T) {
+	hit := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	code, err := NewSender(time.Second, AllowPrivateNetworks()).Send(context.Background(), delivery(srv.URL))
+	if err != nil || code != http.StatusTemporaryRedirect {
+		t.Fatalf("Send = %d, %v", code, err)
+	}
+	if hit {
+		t.Fatal("redirect was followed")
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::248": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::":                   false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"224.0.0.1":            false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:10.0.0.1":      false,
+	}
+	for addr, want := range tests {
+		if got := publicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("publicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
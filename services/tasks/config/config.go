@@ -2,15 +2,102 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
 	Address   string `yaml:"tasks_address" env:"TASKS_ADDRESS" env-default:":8080"`
 	DBAddress string `yaml:"db_address" env:"DB_ADDRESS" env-required:"true"`
+
+	// задачу нельзя перевести в Done, пока открыты её подзадачи
+	SubtaskRollup bool `yaml:"subtask_rollup" env:"SUBTASK_ROLLUP" env-default:"false"`
+
+	// разрешённые переходы статусов: from -> [to...]; пусто => таблица по умолчанию из core.
+	// В env: "todo:in_progress,done;in_progress:todo,done"
+	StatusTransitions StatusTransitions `yaml:"status_transitions" env:"STATUS_TRANSITIONS"`
+
+	// корзина: сколько хранить удалённое и как часто чистить; 0 => не чистить
+	TrashRetention time.Duration `yaml:"trash_retention" env:"TRASH_RETENTION" env-default:"720h"`
+	PurgeInterval  time.Duration `yaml:"purge_interval" env:"PURGE_INTERVAL" env-default:"1h"`
+
+	// сколько хранится ответ на create-запрос с ключом идемпотентности
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+
+	// как часто WatchTasks перечитывает журнал событий без сигнала от этого экземпляра
+	WatchPollInterval time.Duration `yaml:"watch_poll_interval" env:"WATCH_POLL_INTERVAL" env-default:"5s"`
+
+	// outbox: куда публиковать доменные события (file, memory; пусто => не публиковать),
+	// как часто и по сколько, на сколько relay берёт события в аренду (отправка прохода
+	// должна в неё укладываться), сколько хранить отправленные; 0 => не чистить
+	OutboxPublisher     string        `yaml:"outbox_publisher" env:"OUTBOX_PUBLISHER" env-default:""`
+	OutboxFile          string        `yaml:"outbox_file" env:"OUTBOX_FILE" env-default:"outbox.jsonl"`
+	OutboxRelayInterval time.Duration `yaml:"outbox_relay_interval" env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+	OutboxBatchSize     int           `yaml:"outbox_batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	OutboxMaxBackoff    time.Duration `yaml:"outbox_max_backoff" env:"OUTBOX_MAX_BACKOFF" env-default:"10m"`
+	OutboxLease         time.Duration `yaml:"outbox_lease" env:"OUTBOX_LEASE" env-default:"1m"`
+	OutboxRetention     time.Duration `yaml:"outbox_retention" env:"OUTBOX_RETENTION" env-default:"168h"`
+
+	// вебхуки: как часто и по сколько отправлять доставки (0 => не отправлять), таймаут одной попытки,
+	// сколько попыток до dead, максимальная пауза между ними, сколько хранить завершённые доставки,
+	// можно ли отправлять на loopback и внутренние адреса (только для локального запуска)
+	WebhookDeliveryInterval  time.Duration `yaml:"webhook_delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL" env-default:"1s"`
+	WebhookBatchSize         int           `yaml:"webhook_batch_size" env:"WEBHOOK_BATCH_SIZE" env-default:"20"`
+	WebhookTimeout           time.Duration `yaml:"webhook_timeout" env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+	WebhookMaxAttempts       int           `yaml:"webhook_max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" env-default:"10"`
+	WebhookMaxBackoff        time.Duration `yaml:"webhook_max_backoff" env:"WEBHOOK_MAX_BACKOFF" env-default:"1h"`
+	WebhookDeliveryRetention time.Duration `yaml:"webhook_delivery_retention" env:"WEBHOOK_DELIVERY_RETENTION" env-default:"168h"`
+	WebhookAllowPrivate      bool          `yaml:"webhook_allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" env-default:"false"`
+
+	// аутентификация: JWT в authorization (HS256 с общим секретом и/или RS256 с публичным ключом
+	// из PEM-файла, iss и aud проверяются, если заданы; без секрета и ключа сервис не стартует)
+	// или API-ключ в x-api-key; false => без неё. Bootstrap-ключ ("tm_" и не меньше 32 символов
+	// после него) нужен, чтобы завести первого пользователя, потом его стоит убрать.
+	// Sub токена - id пользователя; права на всё получают только sub из system_subjects
+	// и токены со system_scope в claim scope. Ping и reflection можно оставить открытыми
+	AuthEnabled          bool     `yaml:"auth_enabled" env:"AUTH_ENABLED" env-default:"true"`
+	AuthJWTSecret        string   `yaml:"auth_jwt_secret" env:"AUTH_JWT_SECRET" env-default:""`
+	AuthJWTPublicKeyFile string   `yaml:"auth_jwt_public_key_file" env:"AUTH_JWT_PUBLIC_KEY_FILE" env-default:""`
+	AuthJWTIssuer        string   `yaml:"auth_jwt_issuer" env:"AUTH_JWT_ISSUER" env-default:""`
+	AuthJWTAudience      string   `yaml:"auth_jwt_audience" env:"AUTH_JWT_AUDIENCE" env-default:""`
+	AuthBootstrapAPIKey  string   `yaml:"auth_bootstrap_api_key" env:"AUTH_BOOTSTRAP_API_KEY" env-default:""`
+	AuthSystemSubjects   []string `yaml:"auth_system_subjects" env:"AUTH_SYSTEM_SUBJECTS" env-default:""`
+	AuthSystemScope      string   `yaml:"auth_system_scope" env:"AUTH_SYSTEM_SCOPE" env-default:""`
+	AuthPublicPing       bool     `yaml:"auth_public_ping" env:"AUTH_PUBLIC_PING" env-default:"true"`
+	AuthPublicReflection bool     `yaml:"auth_public_reflection" env:"AUTH_PUBLIC_REFLECTION" env-default:"false"`
+
+	// сколько ждать завершения запросов при остановке; открытые WatchTasks потом обрываются
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"10s"`
+}
+
+// StatusTransitions - таблица переходов статусов, из yaml как map или из env строкой
+type StatusTransitions map[string][]string
+
+// SetValue разбирает "from:to,to;from:to" из env
+func (t *StatusTransitions) SetValue(s string) error {
+	out := make(StatusTransitions)
+	for _, rule := range strings.Split(s, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(rule, ":")
+		if !ok || strings.TrimSpace(from) == "" {
+			return fmt.Errorf("invalid status transition %q", rule)
+		}
+		from = strings.TrimSpace(from)
+		for _, name := range strings.Split(to, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				out[from] = append(out[from], name)
+			}
+		}
+	}
+	*t = out
+	return nil
 }
 
 func MustLoad(configPath string) Config {
@@ -0,0 +1,273 @@
+package core
+
+import (
+	"context"
+	"slices"
+)
+
+// Access
+//
+// Права проверяются у пользователя, от имени которого аутентифицирован запрос.
+// Могут всё только запросы без аутентификации (она выключена) и вызывающие с
+// System; аутентифицированному без пользователя не доступно ничего. Задачи без
+// категории видят и меняют их автор и исполнитель
+
+// SetCategoryMember выдаёт пользователю роль в категории или меняет выданную
+func (s *Service) SetCategoryMember(ctx context.Context, categoryID, userID int64, role Role) (CategoryMember, error) {
+	if categoryID <= 0 || userID <= 0 || !role.Valid() {
+		return CategoryMember{}, ErrCategoryInvalidArgs
+	}
+
+	var m CategoryMember
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// изменения ролей одной категории идут по очереди, иначе два встречных
+		// понижения оставили бы её без владельца
+		if _, err := s.db.LockCategoryForUpdate(ctx, categoryID); err != nil {
+			return err
+		}
+		if err := s.requireCategory(ctx, categoryID, RoleOwner); err != nil {
+			return err
+		}
+		if _, err := s.db.LockUser(ctx, userID); err != nil {
+			return err
+		}
+		if role < RoleOwner {
+			if err := s.checkKeepsOwner(ctx, categoryID, userID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		m, err = s.db.SetCategoryMember(ctx, CategoryMember{CategoryID: categoryID, UserID: userID, Role: role})
+		return err
+	})
+	if err != nil {
+		return CategoryMember{}, err
+	}
+	return m, nil
+}
+
+// RemoveCategoryMember отзывает роль, выданную прямо на категорию;
+// роли из родительских категорий остаются. Последнего владельца не отозвать
+func (s *Service) RemoveCategoryMember(ctx context.Context, categoryID, userID int64) error {
+	if categoryID <= 0 || userID <= 0 {
+		return ErrCategoryInvalidArgs
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.db.LockCategoryForUpdate(ctx, categoryID); err != nil {
+			return err
+		}
+		if err := s.requireCategory(ctx, categoryID, RoleOwner); err != nil {
+			return err
+		}
+		if err := s.checkKeepsOwner(ctx, categoryID, userID); err != nil {
+			return err
+		}
+		return s.db.RemoveCategoryMember(ctx, categoryID, userID)
+	})
+}
+
+// ListCategoryMembers - роли, выданные прямо на категорию
+func (s *Service) ListCategoryMembers(ctx context.Context, categoryID int64) ([]CategoryMember, error) {
+	if categoryID <= 0 {
+		return nil, ErrCategoryInvalidArgs
+	}
+	if _, err := s.db.GetCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+	if err := s.requireCategory(ctx, categoryID, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.db.ListCategoryMembers(ctx, categoryID)
+}
+
+// Helpers
+
+// accessUser - пользователь, права которого проверяются; false => проверок нет.
+// Вызывающий без пользователя и без System получает id 0: у него нет ни ролей,
+// ни своих задач, и проверки ему отказывают
+func accessUser(ctx context.Context) (int64, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.System {
+		return 0, false
+	}
+	return max(p.UserID, 0), true
+}
+
+// requireUser не даёт вызывающему без пользователя создавать то, что потом
+// никому не будет принадлежать
+func requireUser(ctx context.Context) error {
+	if uid, ok := accessUser(ctx); ok && uid == 0 {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// readableBy - пользователь для отбора видимого в списках; nil => видно всё
+func readableBy(ctx context.Context) *int64 {
+	if uid, ok := accessUser(ctx); ok {
+		return &uid
+	}
+	return nil
+}
+
+// requireSystem пропускает только вызовы с System или без аутентификации: настройки
+// всего сервиса (вебхуки, пользователи) не принадлежат ни одной категории
+func requireSystem(ctx context.Context) error {
+	if _, ok := accessUser(ctx); ok {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// requireCategory - ErrPermissionDenied, если роль пользователя в категории ниже min
+func (s *Service) requireCategory(ctx context.Context, categoryID int64, min Role) error {
+	uid, ok := accessUser(ctx)
+	if !ok {
+		return nil
+	}
+	if uid == 0 {
+		return ErrPermissionDenied
+	}
+
+	role, err := s.db.CategoryRole(ctx, categoryID, uid)
+	if err != nil {
+		return err
+	}
+	if role < min {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// requireTask проверяет роль в категории задачи; задача без категории
+// доступна только автору и исполнителю
+func (s *Service) requireTask(ctx context.Context, t Task, min Role) error {
+	uid, ok := accessUser(ctx)
+	if !ok {
+		return nil
+	}
+
+	if t.CategoryID == nil {
+		if sameID(t.CreatorID, &uid) || sameID(t.AssigneeID, &uid) {
+			return nil
+		}
+		return ErrPermissionDenied
+	}
+	return s.requireCategory(ctx, *t.CategoryID, min)
+}
+
+// checkKeepsOwner - ErrLastCategoryOwner, если userID - прямой владелец категории,
+// а других владельцев нет ни у неё, ни у предков. Категория должна быть заблокирована
+func (s *Service) checkKeepsOwner(ctx context.Context, categoryID, userID int64) error {
+	members, err := s.db.ListCategoryMembers(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(members, func(m CategoryMember) bool { return m.UserID == userID && m.Role == RoleOwner }) {
+		return nil
+	}
+
+	n, err := s.db.CountCategoryOwners(ctx, categoryID, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLastCategoryOwner
+	}
+	return nil
+}
+
+// lockTask блокирует задачу до конца транзакции и проверяет роль в её категории:
+// проверка и запись видят одну и ту же задачу. Для изменений вместо requireTaskID
+func (s *Service) lockTask(ctx context.Context, id int64, min Role) (Task, error) {
+	t, err := s.db.LockTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := s.requireTask(ctx, t, min); err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// requireTaskID читает задачу и проверяет роль в её категории
+func (s *Service) requireTaskID(ctx context.Context, id int64, min Role) (Task, error) {
+	t, err := s.db.GetTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if err := s.requireTask(ctx, t, min); err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// requireAnyTask - requireTaskID, в том числе для задачи в корзине
+func (s *Service) requireAnyTask(ctx context.Context, id int64, min Role) error {
+	if _, ok := accessUser(ctx); !ok {
+		return nil
+	}
+
+	tasks, err := s.db.TasksByIDs(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return ErrTaskNotFound
+	}
+	return s.requireTask(ctx, tasks[0], min)
+}
+
+// requireSelf пропускает пользователя только к его собственным данным
+func requireSelf(ctx context.Context, userID int64) error {
+	if uid, ok := accessUser(ctx); ok && uid != userID {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// taskReader отвечает, видна ли задача пользователю из ctx; nil => видно всё.
+// Видимые категории читаются один раз на весь отбор
+func (s *Service) taskReader(ctx context.Context) (func(t Task) bool, error) {
+	uid, ok := accessUser(ctx)
+	if !ok {
+		return nil, nil
+	}
+	if uid == 0 {
+		return func(Task) bool { return false }, nil
+	}
+
+	ids, err := s.db.ReadableCategoryIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	readable := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		readable[id] = true
+	}
+
+	return func(t Task) bool {
+		if t.CategoryID == nil {
+			return sameID(t.CreatorID, &uid) || sameID(t.AssigneeID, &uid)
+		}
+		return readable[*t.CategoryID]
+	}, nil
+}
+
+// filterReadable оставляет видимые пользователю задачи
+func (s *Service) filterReadable(ctx context.Context, tasks []Task) ([]Task, error) {
+	canRead, err := s.taskReader(ctx)
+	if err != nil || canRead == nil {
+		return tasks, err
+	}
+
+	out := tasks[:0]
+	for _, t := range tasks {
+		if canRead(t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"task-manager-microservice/tasks/core"
+)
+
+// usersDB знает только пользователя 1
+type usersDB struct {
+	core.DB
+}
+
+func (usersDB) GetUser(_ context.Context, id int64) (core.User, error) {
+	if id != 1 {
+		return core.User{}, core.ErrUserNotFound
+	}
+	return core.User{ID: id}, nil
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	svc := core.NewService(usersDB{})
+
+	tests := []struct {
+		name string
+		p    core.Principal
+		ok   bool
+	}{
+		{"user", core.Principal{Subject: "1", UserID: 1}, true},
+		{"unknown user", core.Principal{Subject: "2", UserID: 2}, false},
+		{"non-numeric sub", core.Principal{Subject: "svc"}, false},
+		{"system", core.Principal{Subject: "svc", System: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AuthenticateToken(context.Background(), tt.p)
+			if tt.ok && err != nil {
+				t.Fatalf("AuthenticateToken: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, core.ErrUnauthenticated) {
+				t.Fatalf("AuthenticateToken error = %v, want ErrUnauthenticated", err)
+			}
+		})
+	}
+}
+
+func TestPrincipalWithoutUserIsDenied(t *testing.T) {
+	svc := core.NewService(usersDB{})
+	ctx := core.WithPrincipal(context.Background(), core.Principal{Subject: "svc", Method: core.AuthJWT})
+
+	if _, err := svc.CreateWebhook(ctx, core.NewWebhook{URL: "https://example.com/hook"}); !errors.Is(err, core.ErrPermissionDenied) {
+		t.Errorf("CreateWebhook error = %v, want ErrPermissionDenied", err)
+	}
+	if _, err := svc.CreateUser(ctx, core.NewUser{Name: "eve", Email: "eve@example.com"}); !errors.Is(err, core.ErrPermissionDenied) {
+		t.Errorf("CreateUser error = %v, want ErrPermissionDenied", err)
+	}
+	if _, _, err := svc.CreateAPIKey(ctx, core.NewAPIKey{UserID: 1, Name: "key"}); !errors.Is(err, core.ErrPermissionDenied) {
+		t.Errorf("CreateAPIKey error = %v, want ErrPermissionDenied", err)
+	}
+}
+
+// membersDB - одна категория 1 без предков; members - прямые роли в ней
+type membersDB struct {
+	core.DB
+
+	members map[int64]core.Role
+}
+
+func (db *membersDB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (db *membersDB) LockCategoryForUpdate(_ context.Context, id int64) (core.Category, error) {
+	return core.Category{ID: id}, nil
+}
+
+func (db *membersDB) CategoryRole(_ context.Context, _, userID int64) (core.Role, error) {
+	return db.members[userID], nil
+}
+
+func (db *membersDB) ListCategoryMembers(_ context.Context, categoryID int64) ([]core.CategoryMember, error) {
+	var ms []core.CategoryMember
+	for userID, role := range db.members {
+		ms = append(ms, core.CategoryMember{CategoryID: categoryID, UserID: userID, Role: role})
+	}
+	return ms, nil
+}
+
+func (db *membersDB) CountCategoryOwners(_ context.Context, _, exceptUserID int64) (int, error) {
+	n := 0
+	for userID, role := range db.members {
+		if role == core.RoleOwner && userID != exceptUserID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (db *membersDB) RemoveCategoryMember(_ context.Context, _, userID int64) error {
+	delete(db.members, userID)
+	return nil
+}
+
+func TestRemoveLastCategoryOwner(t *testing.T) {
+	db := &membersDB{members: map[int64]core.Role{1: core.RoleOwner, 2: core.RoleOwner, 3: core.RoleEditor}}
+	svc := core.NewService(db)
+	ctx := core.WithPrincipal(context.Background(), core.Principal{Subject: "1", UserID: 1, Method: core.AuthJWT})
+
+	if err := svc.RemoveCategoryMember(ctx, 1, 3); err != nil {
+		t.Fatalf("RemoveCategoryMember(editor): %v", err)
+	}
+	if err := svc.RemoveCategoryMember(ctx, 1, 2); err != nil {
+		t.Fatalf("RemoveCategoryMember(second owner): %v", err)
+	}
+	if err := svc.RemoveCategoryMember(ctx, 1, 1); !errors.Is(err, core.ErrLastCategoryOwner) {
+		t.Fatalf("RemoveCategoryMember(last owner) error = %v, want ErrLastCategoryOwner", err)
+	}
+	if db.members[1] != core.RoleOwner {
+		t.Fatal("last owner was removed")
+	}
+}
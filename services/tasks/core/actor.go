@@ -0,0 +1,68 @@
+package core
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor кладёт в контекст автора изменений для аудита
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает автора изменений или пустую строку
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+type userIDKey struct{}
+
+// WithUserID кладёт в контекст id пользователя, от имени которого идёт запрос
+func WithUserID(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext возвращает id текущего пользователя; false => пользователь неизвестен
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(userIDKey{}).(int64)
+	return id, ok && id > 0
+}
+
+// AuthMethod - чем вызывающий подтвердил, кто он
+type AuthMethod string
+
+const (
+	AuthJWT    AuthMethod = "jwt"
+	AuthAPIKey AuthMethod = "api_key"
+)
+
+// Principal - аутентифицированный вызывающий
+type Principal struct {
+	// Subject - sub из JWT или "api_key:<id>"; пишется в аудит как автор
+	Subject string
+	// UserID - пользователь, от имени которого идёт запрос; 0 => не привязан к пользователю
+	UserID int64
+	Method AuthMethod
+	// System - сервис с правами на всё; выдаётся только явно, вызывающий
+	// без пользователя и без System не может ничего
+	System bool
+}
+
+type principalKey struct{}
+
+// WithPrincipal кладёт в контекст вызывающего, а вместе с ним автора изменений
+// и id пользователя, чтобы они не брались из заголовков клиента
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, p)
+	ctx = WithActor(ctx, p.Subject)
+	if p.UserID > 0 {
+		ctx = WithUserID(ctx, p.UserID)
+	}
+	return ctx
+}
+
+// PrincipalFromContext возвращает вызывающего; false => запрос без аутентификации
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// API keys
+
+const (
+	// apiKeyPrefix отличает ключи сервиса от других секретов, например в логах и сканерах
+	apiKeyPrefix = "tm_"
+	apiKeyBytes  = 32
+	// apiKeyShownLen - сколько символов ключа хранится открыто для списка ключей
+	apiKeyShownLen = len(apiKeyPrefix) + 8
+	maxAPIKeyLen   = 256
+	// minBootstrapKeyLen - ключ из конфига задаёт человек, короткий легко подобрать
+	minBootstrapKeyLen = len(apiKeyPrefix) + 32
+
+	// BootstrapSubject - автор изменений, сделанных ключом из конфига
+	BootstrapSubject = "api_key:bootstrap"
+)
+
+// CreateAPIKey выпускает ключ пользователю. Ключ возвращается только здесь,
+// в базе остаётся его sha256
+func (s *Service) CreateAPIKey(ctx context.Context, in NewAPIKey) (APIKey, string, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.UserID <= 0 || in.Name == "" || len(in.Name) > maxUserFieldLen {
+		return APIKey{}, "", ErrAPIKeyInvalidArgs
+	}
+	// пользователь выпускает ключи только себе
+	if err := requireSelf(ctx, in.UserID); err != nil {
+		return APIKey{}, "", err
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	var created APIKey
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.db.LockUser(ctx, in.UserID); err != nil {
+			return err
+		}
+		created, err = s.db.CreateAPIKey(ctx, in, key[:apiKeyShownLen], hashAPIKey(key))
+		return err
+	})
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	return created, key, nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, f ListAPIKeysFilter) (APIKeyPage, error) {
+	if f.UserID <= 0 || f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return APIKeyPage{}, ErrAPIKeyInvalidArgs
+	}
+	if err := requireSelf(ctx, f.UserID); err != nil {
+		return APIKeyPage{}, err
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListAPIKeys(ctx, f)
+	if err != nil {
+		return APIKeyPage{}, err
+	}
+
+	page := APIKeyPage{Keys: items}
+	if len(items) > size {
+		page.Keys = items[:size]
+		page.Next = &APIKeyCursor{ID: page.Keys[size-1].ID}
+	}
+	return page, nil
+}
+
+// RevokeAPIKey отзывает ключ; повторный отзыв не ошибка
+func (s *Service) RevokeAPIKey(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrAPIKeyInvalidArgs
+	}
+	if _, ok := accessUser(ctx); ok {
+		k, err := s.db.GetAPIKey(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := requireSelf(ctx, k.UserID); err != nil {
+			return err
+		}
+	}
+	return s.db.RevokeAPIKey(ctx, id)
+}
+
+// AuthenticateAPIKey возвращает владельца ключа; неизвестный или отозванный
+// ключ => ErrUnauthenticated
+func (s *Service) AuthenticateAPIKey(ctx context.Context, key string) (Principal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) || len(key) > maxAPIKeyLen {
+		return Principal{}, ErrUnauthenticated
+	}
+
+	hash := hashAPIKey(key)
+	if s.bootstrapKeyHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(s.bootstrapKeyHash)) == 1 {
+		return Principal{Subject: BootstrapSubject, Method: AuthAPIKey, System: true}, nil
+	}
+
+	k, err := s.db.AuthenticateAPIKey(ctx, hash)
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{
+		Subject: "api_key:" + strconv.FormatInt(k.ID, 10),
+		UserID:  k.UserID,
+		Method:  AuthAPIKey,
+	}, nil
+}
+
+// AuthenticateToken проверяет вызывающего из подписанного JWT: без System его
+// sub должен быть id существующего пользователя, иначе ErrUnauthenticated
+func (s *Service) AuthenticateToken(ctx context.Context, p Principal) (Principal, error) {
+	if p.System {
+		return p, nil
+	}
+	if p.UserID <= 0 {
+		return Principal{}, ErrUnauthenticated
+	}
+	if _, err := s.db.GetUser(ctx, p.UserID); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return Principal{}, ErrUnauthenticated
+		}
+		return Principal{}, err
+	}
+	return p, nil
+}
+
+// CheckBootstrapAPIKey проверяет ключ для WithBootstrapAPIKey; пустой ключ => ключа нет
+func CheckBootstrapAPIKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) || len(key) < minBootstrapKeyLen || len(key) > maxAPIKeyLen {
+		return fmt.Errorf("bootstrap api key must start with %q and be %d to %d characters long", apiKeyPrefix, minBootstrapKeyLen, maxAPIKeyLen)
+	}
+	if strings.TrimSpace(key) != key {
+		return errors.New("bootstrap api key must not contain surrounding spaces")
+	}
+	return nil
+}
+
+// Helpers
+
+func newAPIKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate api key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKey - ключ случайный и длинный, соль и медленный хеш ему не нужны
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
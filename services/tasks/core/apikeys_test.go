@@ -0,0 +1,54 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"task-manager-microservice/tasks/core"
+)
+
+func TestCheckBootstrapAPIKey(t *testing.T) {
+	tests := map[string]bool{
+		"":                               true,
+		"tm_" + strings.Repeat("a", 32):  true,
+		"tm_" + strings.Repeat("a", 31):  false,
+		"xx_" + strings.Repeat("a", 32):  false,
+		"tm_" + strings.Repeat("a", 300): false,
+	}
+	for key, ok := range tests {
+		if err := core.CheckBootstrapAPIKey(key); (err == nil) != ok {
+			t.Errorf("CheckBootstrapAPIKey(%.10q) = %v, want ok %v", key, err, ok)
+		}
+	}
+}
+
+// bootstrapDB не знает ни одного ключа
+type bootstrapDB struct {
+	core.DB
+}
+
+func (bootstrapDB) AuthenticateAPIKey(context.Context, string) (core.APIKey, error) {
+	return core.APIKey{}, core.ErrUnauthenticated
+}
+
+func TestAuthenticateBootstrapAPIKey(t *testing.T) {
+	key := "tm_" + strings.Repeat("b", 40)
+	svc := core.NewService(bootstrapDB{}, core.WithBootstrapAPIKey(key))
+
+	p, err := svc.AuthenticateAPIKey(context.Background(), key)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey: %v", err)
+	}
+	if p.Subject != core.BootstrapSubject || p.UserID != 0 || p.Method != core.AuthAPIKey || !p.System {
+		t.Fatalf("principal %+v", p)
+	}
+
+	if _, err := svc.AuthenticateAPIKey(context.Background(), key+"x"); !errors.Is(err, core.ErrUnauthenticated) {
+		t.Fatalf("AuthenticateAPIKey(other key) error = %v, want ErrUnauthenticated", err)
+	}
+	if _, err := core.NewService(bootstrapDB{}).AuthenticateAPIKey(context.Background(), key); !errors.Is(err, core.ErrUnauthenticated) {
+		t.Fatalf("AuthenticateAPIKey without bootstrap key error = %v, want ErrUnauthenticated", err)
+	}
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// Batch
+
+// MaxBatchSize - максимум элементов в одной пакетной операции
+const MaxBatchSize = 500
+
+// BatchMode - что делать с пакетом, если часть элементов не применилась
+type BatchMode int
+
+const (
+	BatchAllOrNothing BatchMode = iota // ошибка любого элемента откатывает весь пакет
+	BatchBestEffort                    // применяются все элементы без ошибок
+)
+
+// BatchResult - результат элемента пакета, Err == nil => элемент применён.
+// У остальных элементов отменённого пакета Err = ErrBatchAborted
+type BatchResult struct {
+	TaskID int64
+	Task   Task // пустая у удаления и при ошибке
+	Err    error
+}
+
+// TaskPatchItem - патч одной задачи в BatchPatchTasks
+type TaskPatchItem struct {
+	ID    int64
+	Patch TaskPatch
+}
+
+// BatchCreateTasks создаёт задачи в одной транзакции
+func (s *Service) BatchCreateTasks(ctx context.Context, items []NewTask, mode BatchMode) ([]BatchResult, error) {
+	if err := checkBatch(len(items), mode); err != nil {
+		return nil, err
+	}
+
+	// CreatorID заполняется в копии, срез вызывающего не меняется
+	items = slices.Clone(items)
+	creator := creatorFromContext(ctx)
+	res := make([]BatchResult, len(items))
+	// проверки в той же транзакции, что и запись: блокировки категорий,
+	// родителей и пользователей держатся до коммита
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		for i := range items {
+			items[i].CreatorID = creator
+			if err := s.checkNewTask(ctx, items[i]); isItemErr(err) {
+				res[i].Err = err
+			} else if err != nil {
+				return err
+			}
+		}
+
+		return execBatch(items, res, mode, func(valid []NewTask) ([]BatchResult, error) {
+			return s.db.BatchCreateTasks(ctx, valid, mode == BatchAllOrNothing)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatchers()
+	return res, nil
+}
+
+// BatchPatchTasks применяет патчи к задачам в одной транзакции. Задачи
+// блокируются до записи, проверки патчей видят те же версии, что и запись
+func (s *Service) BatchPatchTasks(ctx context.Context, items []TaskPatchItem, mode BatchMode) ([]BatchResult, error) {
+	if err := checkBatch(len(items), mode); err != nil {
+		return nil, err
+	}
+	// два патча одной задачи в пакете затёрли бы друг друга
+	seen := make(map[int64]bool, len(items))
+	for _, it := range items {
+		if seen[it.ID] {
+			return nil, ErrTaskInvalidArgs
+		}
+		seen[it.ID] = true
+	}
+
+	res := make([]BatchResult, len(items))
+	tasks := make([]Task, len(items))
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// дерево подзадач - до блокировок задач, как в PatchTask
+		if slices.ContainsFunc(items, func(it TaskPatchItem) bool { return it.Patch.reparents() }) {
+			if err := s.db.LockTaskTree(ctx); err != nil {
+				return err
+			}
+		}
+		for _, i := range lockOrder(len(items), func(i int) int64 { return items[i].ID }) {
+			res[i].TaskID = items[i].ID
+			t, err := s.patchedTask(ctx, items[i].ID, items[i].Patch)
+			if isItemErr(err) {
+				res[i].Err = err
+			} else if err != nil {
+				return err
+			}
+			tasks[i] = t
+		}
+		return s.updateBatch(ctx, tasks, res, mode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatchers()
+	return res, nil
+}
+
+// BatchDeleteTasks переносит задачи в корзину в одной транзакции. Права
+// проверяются у заблокированных задач, до удаления их никто не переместит
+func (s *Service) BatchDeleteTasks(ctx context.Context, ids []int64, mode BatchMode) ([]BatchResult, error) {
+	if err := checkBatch(len(ids), mode); err != nil {
+		return nil, err
+	}
+
+	res := make([]BatchResult, len(ids))
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		for _, i := range lockOrder(len(ids), func(i int) int64 { return ids[i] }) {
+			res[i].TaskID = ids[i]
+			if ids[i] <= 0 {
+				res[i].Err = ErrTaskInvalidArgs
+				continue
+			}
+			if _, err := s.lockTask(ctx, ids[i], RoleEditor); isItemErr(err) {
+				res[i].Err = err
+			} else if err != nil {
+				return err
+			}
+		}
+
+		return execBatch(ids, res, mode, func(valid []int64) ([]BatchResult, error) {
+			return s.db.BatchDeleteTasks(ctx, valid, mode == BatchAllOrNothing)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatchers()
+	return res, nil
+}
+
+// BulkUpdateStatus переводит в статус to все задачи, подходящие под фильтр.
+// Задачи, уже находящиеся в статусе to, в результат не попадают
+func (s *Service) BulkUpdateStatus(ctx context.Context, f ListTasksFilter, to TaskStatus, mode BatchMode) ([]BatchResult, error) {
+	if !isValidStatus(to) {
+		return nil, ErrTaskInvalidArgs
+	}
+	// поиск, пагинация и подсветка к массовому обновлению не относятся
+	if f.Query != "" || f.Highlight || f.After != nil || f.Offset != 0 || f.Limit != 0 || f.WithTotal {
+		return nil, ErrTaskInvalidArgs
+	}
+	if err := checkTasksFilter(ctx, &f); err != nil {
+		return nil, err
+	}
+	if mode != BatchAllOrNothing && mode != BatchBestEffort {
+		return nil, ErrTaskInvalidArgs
+	}
+
+	f.Limit = MaxBatchSize + 1
+
+	var res []BatchResult
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		matched, err := s.db.ListTasks(ctx, f)
+		if err != nil {
+			return err
+		}
+		if len(matched) > MaxBatchSize {
+			return ErrBatchTooLarge
+		}
+
+		var tasks []Task
+		for _, i := range lockOrder(len(matched), func(i int) int64 { return matched[i].ID }) {
+			// выборка могла устареть, проверяется заблокированная версия задачи
+			t, err := s.db.LockTask(ctx, matched[i].ID)
+			if err == nil && t.Status == to {
+				continue
+			}
+			// в выборке и задачи, которые пользователь только видит
+			if err == nil {
+				if err = s.requireTask(ctx, t, RoleEditor); err == nil {
+					err = s.applyPatch(ctx, &t, TaskPatch{Status: &to})
+				}
+			}
+			if err != nil && !isItemErr(err) {
+				return err
+			}
+			res = append(res, BatchResult{TaskID: matched[i].ID, Err: err})
+			tasks = append(tasks, t)
+		}
+		return s.updateBatch(ctx, tasks, res, mode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatchers()
+	return res, nil
+}
+
+// Helpers
+
+func checkBatch(n int, mode BatchMode) error {
+	if n == 0 || mode != BatchAllOrNothing && mode != BatchBestEffort {
+		return ErrTaskInvalidArgs
+	}
+	if n > MaxBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
+
+// isItemErr - ошибка относится к элементу пакета; остальные (БД, отмена ctx)
+// прерывают весь пакет
+func isItemErr(err error) bool {
+	for _, target := range []error{
+		ErrTaskNotFound, ErrTaskInvalidArgs, ErrTaskCycle, ErrTaskHasOpenSubtasks,
+		ErrInvalidTransition, ErrConflict, ErrTaskBlocked,
+		ErrCategoryNotFound, ErrUserNotFound, ErrPermissionDenied,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockOrder - индексы элементов по возрастанию id: пакеты блокируют задачи
+// в одном порядке и не ждут друг друга по кругу
+func lockOrder(n int, id func(i int) int64) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(id(a), id(b)) })
+	return order
+}
+
+// updateBatch записывает проверенные задачи и подгружает теги обновлённым
+func (s *Service) updateBatch(ctx context.Context, tasks []Task, res []BatchResult, mode BatchMode) error {
+	err := execBatch(tasks, res, mode, func(valid []Task) ([]BatchResult, error) {
+		return s.db.BatchUpdateTasks(ctx, valid, mode == BatchAllOrNothing)
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		updated []Task
+		idx     []int
+	)
+	for i, r := range res {
+		if r.Err == nil {
+			updated = append(updated, r.Task)
+			idx = append(idx, i)
+		}
+	}
+	if err := s.attachTags(ctx, updated); err != nil {
+		return err
+	}
+	for j, i := range idx {
+		res[i].Task = updated[j]
+	}
+	return nil
+}
+
+// execBatch передаёт в exec элементы, прошедшие проверку, и раскладывает
+// результаты по исходным позициям. В режиме BatchAllOrNothing пакет с
+// ошибками проверки до БД не доходит
+func execBatch[T any](items []T, res []BatchResult, mode BatchMode, exec func([]T) ([]BatchResult, error)) error {
+	var (
+		valid []T
+		idx   []int
+	)
+	for i, r := range res {
+		if r.Err == nil {
+			valid = append(valid, items[i])
+			idx = append(idx, i)
+		}
+	}
+
+	if mode == BatchAllOrNothing && len(valid) < len(items) {
+		for _, i := range idx {
+			res[i].Err = ErrBatchAborted
+		}
+		return nil
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+
+	out, err := exec(valid)
+	if err != nil {
+		return err
+	}
+	for j, i := range idx {
+		if out[j].TaskID == 0 {
+			out[j].TaskID = res[i].TaskID
+		}
+		res[i] = out[j]
+	}
+	return nil
+}
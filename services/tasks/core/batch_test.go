@@ -0,0 +1,70 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLockOrder(t *testing.T) {
+	ids := []int64{30, 10, 20, 10}
+	got := lockOrder(len(ids), func(i int) int64 { return ids[i] })
+	if want := []int{1, 3, 2, 0}; !slices.Equal(got, want) {
+		t.Fatalf("lockOrder = %v, want %v", got, want)
+	}
+}
+
+func TestExecBatch(t *testing.T) {
+	// exec получает только элементы без ошибки проверки и отвечает по порядку
+	exec := func(calls *[][]int64) func([]int64) ([]BatchResult, error) {
+		return func(valid []int64) ([]BatchResult, error) {
+			*calls = append(*calls, valid)
+			out := make([]BatchResult, len(valid))
+			for j, id := range valid {
+				if id == 3 {
+					out[j].Err = ErrConflict
+				}
+			}
+			return out, nil
+		}
+	}
+	items := []int64{1, 2, 3}
+
+	tests := []struct {
+		name  string
+		mode  BatchMode
+		check []error // ошибки проверки по элементам
+		calls [][]int64
+		want  []error
+	}{
+		{"best effort", BatchBestEffort, []error{nil, ErrPermissionDenied, nil},
+			[][]int64{{1, 3}}, []error{nil, ErrPermissionDenied, ErrConflict}},
+		{"all or nothing aborts before the db", BatchAllOrNothing, []error{nil, ErrPermissionDenied, nil},
+			nil, []error{ErrBatchAborted, ErrPermissionDenied, ErrBatchAborted}},
+		{"all or nothing", BatchAllOrNothing, []error{nil, nil, nil},
+			[][]int64{{1, 2, 3}}, []error{nil, nil, ErrConflict}},
+		{"nothing valid", BatchBestEffort, []error{ErrTaskNotFound, ErrTaskNotFound, ErrTaskNotFound},
+			nil, []error{ErrTaskNotFound, ErrTaskNotFound, ErrTaskNotFound}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := make([]BatchResult, len(items))
+			for i := range res {
+				res[i] = BatchResult{TaskID: items[i], Err: tt.check[i]}
+			}
+
+			var calls [][]int64
+			if err := execBatch(items, res, tt.mode, exec(&calls)); err != nil {
+				t.Fatalf("execBatch: %v", err)
+			}
+			if !slices.EqualFunc(calls, tt.calls, slices.Equal) {
+				t.Fatalf("exec calls %v, want %v", calls, tt.calls)
+			}
+			for i, r := range res {
+				if r.TaskID != items[i] || !errors.Is(r.Err, tt.want[i]) || (tt.want[i] == nil) != (r.Err == nil) {
+					t.Errorf("res[%d] = %+v, want error %v", i, r, tt.want[i])
+				}
+			}
+		})
+	}
+}
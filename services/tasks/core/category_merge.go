@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"slices"
+)
+
+// Category merge
+
+// MergeCategories переносит задачи source категорий в target и удаляет source категории
+func (s *Service) MergeCategories(ctx context.Context, targetID int64, sourceIDs []int64) (CategoryMerge, error) {
+	sourceIDs, ok := normalizeIDs(sourceIDs)
+	if !ok || targetID <= 0 || len(sourceIDs) == 0 || slices.Contains(sourceIDs, targetID) {
+		return CategoryMerge{}, ErrCategoryInvalidArgs
+	}
+
+	var m CategoryMerge
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// дочерние категории source переезжают в target: дерево не должно
+		// меняться между проверкой предков и переносом
+		if err := s.db.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		for _, id := range append([]int64{targetID}, sourceIDs...) {
+			if err := s.requireCategory(ctx, id, RoleOwner); err != nil {
+				return err
+			}
+		}
+
+		// target не может лежать внутри source
+		ancestors, err := s.db.CategoryAncestors(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		for _, id := range sourceIDs {
+			if slices.Contains(ancestors, id) {
+				return ErrCategoryCycle
+			}
+		}
+
+		m, err = s.db.MergeCategories(ctx, targetID, sourceIDs)
+		return err
+	})
+	if err != nil {
+		return CategoryMerge{}, err
+	}
+	s.notifyWatchers()
+	return m, nil
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"slices"
+)
+
+// Category tree
+
+// MoveCategory переносит категорию под parentID, 0 => сделать корневой
+func (s *Service) MoveCategory(ctx context.Context, id, parentID int64) (Category, error) {
+	if id <= 0 || parentID < 0 {
+		return Category{}, ErrCategoryInvalidArgs
+	}
+
+	var c Category
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// без блокировки два встречных переноса (A под B и B под A) оба прошли бы
+		// проверку предков и замкнули цикл
+		if err := s.db.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		if err := s.requireCategory(ctx, id, RoleOwner); err != nil {
+			return err
+		}
+
+		var parent *int64
+		if parentID != 0 {
+			if err := s.checkCategoryParent(ctx, id, parentID); err != nil {
+				return err
+			}
+			if err := s.requireCategory(ctx, parentID, RoleEditor); err != nil {
+				return err
+			}
+			parent = &parentID
+		}
+
+		var err error
+		c, err = s.db.MoveCategory(ctx, id, parent)
+		return err
+	})
+	if err != nil {
+		return Category{}, err
+	}
+	return c, nil
+}
+
+// GetCategoryTree собирает категорию со всеми вложенными категориями.
+// rootID == 0 => весь лес категорий; пользователь видит в нём только свои
+// категории, и корнями становятся те, чей родитель ему не виден
+func (s *Service) GetCategoryTree(ctx context.Context, rootID int64) ([]CategoryNode, error) {
+	if rootID < 0 {
+		return nil, ErrCategoryInvalidArgs
+	}
+
+	cats, err := s.db.CategorySubtree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	// роль действует и во вложенных категориях, поэтому поддерево видимой категории видно целиком
+	if rootID != 0 {
+		if err := s.requireCategory(ctx, rootID, RoleViewer); err != nil {
+			return nil, err
+		}
+	} else if cats, err = s.readableCategories(ctx, cats); err != nil {
+		return nil, err
+	}
+	visible := make(map[int64]bool, len(cats))
+	for _, c := range cats {
+		visible[c.ID] = true
+	}
+
+	children := make(map[int64][]Category, len(cats))
+	var roots []Category
+	for _, c := range cats {
+		if c.ID == rootID || c.ParentID == nil || !visible[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var build func(c Category) CategoryNode
+	build = func(c Category) CategoryNode {
+		node := CategoryNode{Category: c}
+		for _, child := range children[c.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	out := make([]CategoryNode, 0, len(roots))
+	for _, c := range roots {
+		out = append(out, build(c))
+	}
+	return out, nil
+}
+
+// readableCategories оставляет категории, видимые пользователю из ctx
+func (s *Service) readableCategories(ctx context.Context, cats []Category) ([]Category, error) {
+	uid, ok := accessUser(ctx)
+	if !ok {
+		return cats, nil
+	}
+
+	ids, err := s.db.ReadableCategoryIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	readable := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		readable[id] = true
+	}
+
+	out := cats[:0]
+	for _, c := range cats {
+		if readable[c.ID] {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// checkCategoryParent проверяет, что parentID можно назначить родителем категории id
+func (s *Service) checkCategoryParent(ctx context.Context, id, parentID int64) error {
+	if parentID == id {
+		return ErrCategoryCycle
+	}
+
+	if _, err := s.db.LockCategory(ctx, parentID); err != nil {
+		return err // ErrCategoryNotFound -> NotFound
+	}
+
+	ancestors, err := s.db.CategoryAncestors(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(ancestors, id) {
+		return ErrCategoryCycle
+	}
+	return nil
+}
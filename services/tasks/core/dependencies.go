@@ -0,0 +1,113 @@
+package core
+
+import "context"
+
+// Dependencies
+
+type TaskDependencies struct {
+	BlockedBy []Task // задачи, которые блокируют эту
+	Blocking  []Task // задачи, которые блокирует эта
+}
+
+func (s *Service) AddDependency(ctx context.Context, taskID, blockedByID int64) error {
+	if taskID <= 0 || blockedByID <= 0 {
+		return ErrTaskInvalidArgs
+	}
+	if taskID == blockedByID {
+		return ErrDependencyCycle
+	}
+
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		// цикл может замкнуться через связи, которые добавляют параллельно
+		// по другим задачам, поэтому новые связи добавляются по одной.
+		// Граф блокируется раньше задач, чтобы не ждать по кругу
+		if err := s.db.LockDependencyGraph(ctx); err != nil {
+			return err
+		}
+		// меняется задача taskID, блокирующую достаточно видеть;
+		// задачи блокируются по возрастанию id
+		for _, id := range []int64{min(taskID, blockedByID), max(taskID, blockedByID)} {
+			role := RoleViewer
+			if id == taskID {
+				role = RoleEditor
+			}
+			if _, err := s.lockTask(ctx, id, role); err != nil {
+				return err
+			}
+		}
+
+		// цикл: blockedByID уже (транзитивно) ждёт taskID
+		cycle, err := s.db.DependencyPathExists(ctx, blockedByID, taskID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+
+		return s.db.AddDependency(ctx, taskID, blockedByID)
+	})
+}
+
+func (s *Service) RemoveDependency(ctx context.Context, taskID, blockedByID int64) error {
+	if taskID <= 0 || blockedByID <= 0 {
+		return ErrTaskInvalidArgs
+	}
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.lockTask(ctx, taskID, RoleEditor); err != nil {
+			return err
+		}
+		return s.db.RemoveDependency(ctx, taskID, blockedByID)
+	})
+}
+
+func (s *Service) ListDependencies(ctx context.Context, taskID int64) (TaskDependencies, error) {
+	if taskID <= 0 {
+		return TaskDependencies{}, ErrTaskInvalidArgs
+	}
+	if _, err := s.requireTaskID(ctx, taskID, RoleViewer); err != nil {
+		return TaskDependencies{}, err
+	}
+
+	blockedBy, err := s.db.ListBlockers(ctx, taskID)
+	if err != nil {
+		return TaskDependencies{}, err
+	}
+	blocking, err := s.db.ListBlocked(ctx, taskID)
+	if err != nil {
+		return TaskDependencies{}, err
+	}
+
+	// связи с невидимыми задачами не показываются
+	if blockedBy, err = s.filterReadable(ctx, blockedBy); err != nil {
+		return TaskDependencies{}, err
+	}
+	if blocking, err = s.filterReadable(ctx, blocking); err != nil {
+		return TaskDependencies{}, err
+	}
+
+	if err := s.attachTags(ctx, blockedBy); err != nil {
+		return TaskDependencies{}, err
+	}
+	if err := s.attachTags(ctx, blocking); err != nil {
+		return TaskDependencies{}, err
+	}
+
+	return TaskDependencies{BlockedBy: blockedBy, Blocking: blocking}, nil
+}
+
+// checkNotBlocked не даёт начать или завершить задачу, пока не завершены блокирующие
+func (s *Service) checkNotBlocked(ctx context.Context, taskID int64, from, to TaskStatus) error {
+	if from == to || (to != InProgress && to != Done) {
+		return nil
+	}
+
+	open, err := s.db.CountOpenBlockers(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrTaskBlocked
+	}
+	return nil
+}
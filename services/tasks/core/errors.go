@@ -7,6 +7,9 @@ var (
 	ErrCategoryAlreadyExists = errors.New("category already exists")
 	ErrCategoryNotFound      = errors.New("category not found")
 	ErrCategoryInvalidArgs   = errors.New("category invalid args")
+	ErrCategoryNotEmpty      = errors.New("category has tasks")
+	ErrCategoryCycle         = errors.New("category hierarchy cycle")
+	ErrCategoryDeleted       = errors.New("category is in the trash")
 )
 
 // Tasks errors
@@ -14,4 +17,62 @@ var (
 	ErrTaskAlreadyExists = errors.New("task already exists")
 	ErrTaskNotFound      = errors.New("task not found")
 	ErrTaskInvalidArgs   = errors.New("task invalid args")
+
+	ErrTaskCycle           = errors.New("task hierarchy cycle")
+	ErrTaskHasOpenSubtasks = errors.New("task has open subtasks")
+	ErrInvalidTransition   = errors.New("invalid task status transition")
+	ErrConflict            = errors.New("task version conflict")
+)
+
+// Users errors
+var (
+	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserInvalidArgs   = errors.New("user invalid args")
+)
+
+// Auth errors
+var (
+	ErrUnauthenticated   = errors.New("unauthenticated")
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrAPIKeyInvalidArgs = errors.New("api key invalid args")
+)
+
+// Tags errors
+var (
+	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagInvalidArgs   = errors.New("tag invalid args")
+)
+
+// Dependencies errors
+var (
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrDependencyCycle    = errors.New("dependency cycle")
+	ErrTaskBlocked        = errors.New("task is blocked by unfinished tasks")
+)
+
+// Idempotency errors
+var (
+	ErrIdempotencyKeyInvalid = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key reused with a different request")
+)
+
+// Access errors
+var (
+	ErrPermissionDenied       = errors.New("permission denied")
+	ErrCategoryMemberNotFound = errors.New("category member not found")
+	ErrLastCategoryOwner      = errors.New("category must keep at least one owner")
+)
+
+// Webhooks errors
+var (
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrWebhookInvalidArgs = errors.New("webhook invalid args")
+)
+
+// Batch errors
+var (
+	ErrBatchAborted  = errors.New("batch aborted: another item failed")
+	ErrBatchTooLarge = errors.New("batch too large")
 )
@@ -1,9 +1,232 @@
 package core
 
+import "time"
+
+// Пагинация
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// TaskSort - порядок выдачи ListTasks
+type TaskSort int
+
+const (
+	SortCreatedAtDesc TaskSort = iota // created_at DESC
+	SortPriorityDesc                  // priority DESC, created_at DESC
+	SortRelevance                     // ts_rank DESC, только с Query
+)
+
+// TaskCursor - последняя выданная задача, keyset пагинация по (priority | rank,) created_at, id
+type TaskCursor struct {
+	Sort      TaskSort     `json:"s"`
+	Priority  TaskPriority `json:"p,omitempty"`
+	Rank      float32      `json:"r,omitempty"`
+	CreatedAt time.Time    `json:"c"`
+	ID        int64        `json:"i"`
+}
+
 type ListTasksFilter struct {
-	Status          *TaskStatus `json:"status"`
-	CategoryID      *int64      `json:"category_id"`
-	WithoutCategory bool        `json:"without_category"`
-	Limit           int         `json:"limit"`
-	Offset          int         `json:"offset"`
+	Status               *TaskStatus   `json:"status"`
+	Priority             *TaskPriority `json:"priority"`
+	CategoryID           *int64        `json:"category_id"`
+	IncludeSubcategories bool          `json:"include_subcategories"` // вместе с CategoryID: и во вложенных категориях
+	ParentID             *int64        `json:"parent_id"`             // только прямые подзадачи
+	WithoutCategory      bool          `json:"without_category"`
+	DueBefore            *time.Time    `json:"due_before"` // due_at < DueBefore
+	DueAfter             *time.Time    `json:"due_after"`  // due_at >= DueAfter
+	Overdue              bool          `json:"overdue"`    // срок прошёл, задача не done/archived
+	Query                string        `json:"query"`      // полнотекстовый поиск по name и description
+	CreatorID            *int64        `json:"creator_id"`
+	AssigneeID           *int64        `json:"assignee_id"`
+	AssignedToMe         bool          `json:"assigned_to_me"` // AssigneeID = пользователь из ctx
+	Unassigned           bool          `json:"unassigned"`
+	Highlight            bool          `json:"highlight"` // подсветка совпадений, только с Query
+	AnyTagIDs            []int64       `json:"any_tag_ids"`
+	AllTagIDs            []int64       `json:"all_tag_ids"`
+	Sort                 TaskSort      `json:"sort"`
+	After                *TaskCursor   `json:"after"` // Nil => первая страница
+	WithTotal            bool          `json:"with_total"`
+	Limit                int           `json:"limit"`
+	Offset               int           `json:"offset"`
+
+	// заполняет сервис: только задачи, которые видит этот пользователь
+	ReadableBy *int64 `json:"-"`
+}
+
+type TaskPage struct {
+	Tasks      []Task
+	Next       *TaskCursor      // Nil => страниц больше нет
+	Total      *int64           // Nil, если не запрашивали
+	Highlights map[int64]string // task id => фрагмент, только при Highlight
+}
+
+// TaskHit - задача, найденная полнотекстовым поиском
+type TaskHit struct {
+	Task
+	Rank    float32 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+// CategoryCursor - последняя выданная категория, keyset по position, lower(name), id
+type CategoryCursor struct {
+	Position int32  `json:"p"`
+	Name     string `json:"n"`
+	ID       int64  `json:"i"`
+}
+
+type ListCategoriesFilter struct {
+	After      *CategoryCursor `json:"after"`
+	Limit      int             `json:"limit"`
+	WithCounts bool            `json:"with_counts"` // посчитать задачи категорий по статусам
+
+	// заполняет сервис: только категории, которые видит этот пользователь
+	ReadableBy *int64 `json:"-"`
+}
+
+type CategoryPage struct {
+	Categories []Category
+	Next       *CategoryCursor
+	Counts     map[int64]TaskCounts // category id => счётчики, только при WithCounts
+}
+
+// TagCursor - последний выданный тег, keyset по lower(name), id
+type TagCursor struct {
+	Name string `json:"n"`
+	ID   int64  `json:"i"`
+}
+
+type ListTagsFilter struct {
+	After *TagCursor `json:"after"`
+	Limit int        `json:"limit"`
+}
+
+type TagPage struct {
+	Tags []Tag
+	Next *TagCursor
+}
+
+// UserCursor - последний выданный пользователь, keyset по id
+type UserCursor struct {
+	ID int64 `json:"i"`
+}
+
+type ListUsersFilter struct {
+	After *UserCursor
+	Limit int
+}
+
+type UserPage struct {
+	Users []User
+	Next  *UserCursor
+}
+
+// APIKeyCursor - последний выданный ключ, keyset по id
+type APIKeyCursor struct {
+	ID int64 `json:"i"`
+}
+
+// ListAPIKeysFilter - ключи одного пользователя, отозванные тоже
+type ListAPIKeysFilter struct {
+	UserID int64
+	After  *APIKeyCursor
+	Limit  int
+}
+
+type APIKeyPage struct {
+	Keys []APIKey
+	Next *APIKeyCursor
+}
+
+// WebhookCursor - последний выданный вебхук, keyset по id
+type WebhookCursor struct {
+	ID int64 `json:"i"`
+}
+
+type ListWebhooksFilter struct {
+	After *WebhookCursor
+	Limit int
+}
+
+type WebhookPage struct {
+	Webhooks []Webhook
+	Next     *WebhookCursor
+}
+
+// DeliveryCursor - последняя выданная доставка, keyset по id DESC
+type DeliveryCursor struct {
+	ID int64 `json:"i"`
+}
+
+type ListDeliveriesFilter struct {
+	WebhookID int64           // 0 => все вебхуки
+	Status    *DeliveryStatus // Nil => любой статус
+	After     *DeliveryCursor
+	Limit     int
+}
+
+type DeliveryPage struct {
+	Deliveries []WebhookDelivery
+	Next       *DeliveryCursor
+}
+
+// TaskEventCursor - последнее выданное событие, keyset по id DESC
+type TaskEventCursor struct {
+	ID int64 `json:"i"`
+}
+
+type ListTaskEventsFilter struct {
+	TaskID int64
+	After  *TaskEventCursor
+	Limit  int
+}
+
+type TaskEventPage struct {
+	Events []TaskEvent
+	Next   *TaskEventCursor
+}
+
+type TrashKind int16
+
+const (
+	TrashTasks      TrashKind = 0
+	TrashCategories TrashKind = 1
+)
+
+// DeletedCursor - последняя выданная запись корзины, keyset по deleted_at DESC, id DESC
+type DeletedCursor struct {
+	Kind      TrashKind `json:"k"`
+	DeletedAt time.Time `json:"d"`
+	ID        int64     `json:"i"`
+}
+
+type ListDeletedFilter struct {
+	Kind  TrashKind
+	After *DeletedCursor
+	Limit int
+
+	// заполняет сервис: только то, что видит этот пользователь
+	ReadableBy *int64
+}
+
+type DeletedPage struct {
+	Tasks      []Task
+	Categories []Category
+	Next       *DeletedCursor
+}
+
+// PurgeResult - сколько записей удалено из корзины окончательно
+type PurgeResult struct {
+	Tasks      int64
+	Categories int64
+}
+
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return limit
 }
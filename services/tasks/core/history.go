@@ -0,0 +1,31 @@
+package core
+
+import "context"
+
+// History
+
+func (s *Service) ListTaskHistory(ctx context.Context, f ListTaskEventsFilter) (TaskEventPage, error) {
+	if f.TaskID <= 0 || f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return TaskEventPage{}, ErrTaskInvalidArgs
+	}
+	// история остаётся доступной и у задачи в корзине
+	if err := s.requireAnyTask(ctx, f.TaskID, RoleViewer); err != nil {
+		return TaskEventPage{}, err
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListTaskEvents(ctx, f)
+	if err != nil {
+		return TaskEventPage{}, err
+	}
+
+	page := TaskEventPage{Events: items}
+	if len(items) > size {
+		page.Events = items[:size]
+		page.Next = &TaskEventCursor{ID: page.Events[size-1].ID}
+	}
+	return page, nil
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Idempotency
+
+// DefaultIdempotencyTTL - сколько хранится ответ на запрос с ключом идемпотентности
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLen - ограничение длины ключа в байтах
+const maxIdempotencyKeyLen = 255
+
+// Области ключей: один и тот же ключ можно использовать в разных методах
+const (
+	scopeCreateTask     = "create_task"
+	scopeCreateCategory = "create_category"
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey кладёт в контекст ключ идемпотентности create-запроса
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKeyFromContext возвращает ключ идемпотентности или пустую строку
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// PurgeIdempotencyKeys удаляет просроченные ключи идемпотентности
+func (s *Service) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	return s.db.PurgeIdempotencyKeys(ctx, time.Now())
+}
+
+// Helpers
+
+// idempotent выполняет create не больше одного раза на ключ из ctx.
+// Повтор с тем же ключом и тем же запросом получает сохранённый ответ,
+// с другим запросом - ErrIdempotencyKeyReused. Ошибка create ключ не занимает
+func idempotent[T any](ctx context.Context, s *Service, scope string, req any, create func(ctx context.Context) (T, error)) (T, error) {
+	var out T
+
+	key := strings.TrimSpace(IdempotencyKeyFromContext(ctx))
+	if key == "" {
+		return create(ctx)
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		return out, ErrIdempotencyKeyInvalid
+	}
+
+	// одинаковые ключи разных вызывающих не должны отдавать чужой ответ
+	if p, ok := PrincipalFromContext(ctx); ok {
+		scope += ":" + p.Subject
+	}
+
+	hash, err := requestHash(req)
+	if err != nil {
+		return out, err
+	}
+
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		rec, found, err := s.db.ClaimIdempotencyKey(ctx, IdempotencyKey{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(s.idempotencyTTL),
+		})
+		if err != nil {
+			return err
+		}
+		if found {
+			if rec.RequestHash != hash {
+				return ErrIdempotencyKeyReused
+			}
+			return json.Unmarshal(rec.Response, &out)
+		}
+
+		if out, err = create(ctx); err != nil {
+			return err
+		}
+
+		resp, err := json.Marshal(out)
+		if err != nil {
+			return fmt.Errorf("marshal idempotent response: %w", err)
+		}
+		return s.db.SaveIdempotencyResponse(ctx, scope, key, resp)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+func requestHash(req any) (string, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("marshal idempotent request: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// keysDB хранит ключи идемпотентности в памяти; WithTx без отката
+type keysDB struct {
+	DB
+
+	keys map[string]IdempotencyKey
+}
+
+func (db *keysDB) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (db *keysDB) ClaimIdempotencyKey(_ context.Context, k IdempotencyKey) (IdempotencyKey, bool, error) {
+	if rec, ok := db.keys[k.Scope+"/"+k.Key]; ok {
+		return rec, true, nil
+	}
+	db.keys[k.Scope+"/"+k.Key] = k
+	return IdempotencyKey{}, false, nil
+}
+
+func (db *keysDB) SaveIdempotencyResponse(_ context.Context, scope, key string, response []byte) error {
+	rec := db.keys[scope+"/"+key]
+	rec.Response = response
+	db.keys[scope+"/"+key] = rec
+	return nil
+}
+
+func TestIdempotent(t *testing.T) {
+	s := NewService(&keysDB{keys: map[string]IdempotencyKey{}})
+
+	calls := 0
+	create := func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+	call := func(ctx context.Context, req string) (int, error) {
+		return idempotent(ctx, s, scopeCreateTask, req, create)
+	}
+
+	alice := WithIdempotencyKey(WithPrincipal(context.Background(), Principal{Subject: "1", UserID: 1}), "key")
+	if got, err := call(alice, "a"); err != nil || got != 1 {
+		t.Fatalf("first call = %d, %v", got, err)
+	}
+	if got, err := call(alice, "a"); err != nil || got != 1 || calls != 1 {
+		t.Fatalf("repeat = %d, %v after %d creates, want the saved response", got, err, calls)
+	}
+	if _, err := call(alice, "b"); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("other request error = %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// тот же ключ другого вызывающего - другой запрос
+	bob := WithIdempotencyKey(WithPrincipal(context.Background(), Principal{Subject: "2", UserID: 2}), "key")
+	if got, err := call(bob, "b"); err != nil || got != 2 {
+		t.Fatalf("other caller = %d, %v", got, err)
+	}
+
+	// без ключа create выполняется каждый раз
+	if got, err := call(context.Background(), "a"); err != nil || got != 3 {
+		t.Fatalf("no key = %d, %v", got, err)
+	}
+}
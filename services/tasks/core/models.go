@@ -1,6 +1,9 @@
 package core
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type TaskStatus int16
 
@@ -11,17 +14,243 @@ const (
 	Archived   TaskStatus = 3
 )
 
+type TaskPriority int16
+
+const (
+	PriorityLow    TaskPriority = 0
+	PriorityMedium TaskPriority = 1
+	PriorityHigh   TaskPriority = 2
+	PriorityUrgent TaskPriority = 3
+)
+
 type Task struct {
+	ID          int64        `db:"id"`
+	CategoryID  *int64       `db:"category_id"` // Nil без категории
+	ParentID    *int64       `db:"parent_id"`   // Nil у задачи верхнего уровня
+	Name        string       `db:"name"`
+	Description string       `db:"description"`
+	Status      TaskStatus   `db:"status"`
+	Priority    TaskPriority `db:"priority"`
+	DueAt       *time.Time   `db:"due_at"` // Nil без срока
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	CompletedAt *time.Time   `db:"completed_at"` // момент перехода в Done
+	ArchivedAt  *time.Time   `db:"archived_at"`  // момент перехода в Archived
+	DeletedAt   *time.Time   `db:"deleted_at"`   // Nil вне корзины
+	Version     int64        `db:"version"`      // растёт при каждой записи, с 1
+	CreatorID   *int64       `db:"creator_id"`   // Nil, если автор неизвестен или удалён
+	AssigneeID  *int64       `db:"assignee_id"`  // Nil => не назначена
+
+	Tags []Tag `db:"-"`
+}
+
+type TaskEventType int16
+
+const (
+	EventCreated  TaskEventType = 0
+	EventUpdated  TaskEventType = 1
+	EventDeleted  TaskEventType = 2
+	EventRestored TaskEventType = 3
+	EventPurged   TaskEventType = 4
+)
+
+// FieldChange - изменение одного поля задачи, значения в JSON
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// TaskEvent - запись аудита задачи
+type TaskEvent struct {
+	ID        int64         `db:"id"`
+	TaskID    int64         `db:"task_id"`
+	Type      TaskEventType `db:"type"`
+	Actor     string        `db:"actor"` // пусто, если автор неизвестен
+	Changes   []FieldChange `db:"-"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+// TaskNode - задача с вложенными подзадачами
+type TaskNode struct {
+	Task
+	Children []TaskNode
+}
+
+type Category struct {
 	ID          int64      `db:"id"`
-	CategoryID  *int64     `db:"category_id"` // Nil без категории
+	ParentID    *int64     `db:"parent_id"` // Nil у корневой категории
 	Name        string     `db:"name"`
 	Description string     `db:"description"`
-	Status      TaskStatus `db:"status"`
+	Color       string     `db:"color"` // #rrggbb, пусто => без цвета
+	Icon        string     `db:"icon"`
+	Position    int32      `db:"position"` // ручная сортировка, меньше => выше
 	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at"` // Nil вне корзины
 }
 
-type Category struct {
+// IdempotencyKey - занятый ключ идемпотентности и сохранённый ответ в JSON
+type IdempotencyKey struct {
+	Scope       string          `db:"scope"`
+	Key         string          `db:"key"`
+	RequestHash string          `db:"request_hash"` // sha256 запроса
+	Response    json.RawMessage `db:"response"`
+	CreatedAt   time.Time       `db:"created_at"`
+	ExpiresAt   time.Time       `db:"expires_at"`
+}
+
+// OutboxEvent - доменное событие, ожидающее публикации. ID растёт монотонно,
+// подписчики дедуплицируют повторные доставки по нему
+type OutboxEvent struct {
+	ID          int64           `db:"id" json:"id"`
+	Type        string          `db:"type" json:"type"`
+	AggregateID int64           `db:"aggregate_id" json:"aggregate_id"` // id задачи или категории
+	Payload     json.RawMessage `db:"payload" json:"payload"`
+	Actor       string          `db:"actor" json:"actor,omitempty"`
+	CreatedAt   time.Time       `db:"created_at" json:"created_at"`
+	Attempts    int             `db:"attempts" json:"-"` // неудачные попытки до этой
+}
+
+type User struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type NewUser struct {
+	Name  string
+	Email string
+}
+
+// Role - роль пользователя в категории. Роль действует и во вложенных категориях,
+// старшая роль включает права младших
+type Role int16
+
+const (
+	RoleViewer Role = 1 // видит категорию и её задачи
+	RoleEditor Role = 2 // создаёт, меняет и удаляет задачи, создаёт подкатегории
+	RoleOwner  Role = 3 // меняет, перемещает и удаляет категорию, раздаёт роли
+)
+
+func (r Role) Valid() bool {
+	return r >= RoleViewer && r <= RoleOwner
+}
+
+// CategoryMember - роль пользователя, выданная прямо на категорию
+type CategoryMember struct {
+	CategoryID int64     `db:"category_id"`
+	UserID     int64     `db:"user_id"`
+	Role       Role      `db:"role"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// APIKey - ключ доступа пользователя к API; сам ключ не хранится, только его хеш
+type APIKey struct {
+	ID         int64      `db:"id"`
+	UserID     int64      `db:"user_id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"` // начало ключа, чтобы его можно было узнать в списке
+	CreatedAt  time.Time  `db:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+type NewAPIKey struct {
+	UserID int64
+	Name   string
+}
+
+// Webhook - подписка HTTP-получателя на события outbox
+type Webhook struct {
+	ID         int64
+	URL        string
+	Secret     string   // ключ HMAC-подписи
+	EventTypes []string // пусто => все события
+	CategoryID *int64   // Nil => события всех категорий
+	CreatedAt  time.Time
+}
+
+type NewWebhook struct {
+	URL        string
+	Secret     string // пусто => генерируется
+	EventTypes []string
+	CategoryID *int64
+}
+
+type DeliveryStatus int16
+
+const (
+	DeliveryPending   DeliveryStatus = 0
+	DeliveryDelivered DeliveryStatus = 1
+	DeliveryDead      DeliveryStatus = 2 // попытки исчерпаны
+)
+
+// WebhookDelivery - отправка одного события одному вебхуку со всеми попытками
+type WebhookDelivery struct {
+	ID            int64           `db:"id"`
+	WebhookID     int64           `db:"webhook_id"`
+	EventID       int64           `db:"event_id"` // id события outbox
+	EventType     string          `db:"event_type"`
+	Payload       json.RawMessage `db:"payload"` // тело запроса
+	Status        DeliveryStatus  `db:"status"`
+	Attempts      int             `db:"attempts"`
+	ResponseCode  int             `db:"response_code"` // 0 => ответа не было
+	LastError     string          `db:"last_error"`
+	CreatedAt     time.Time       `db:"created_at"`
+	NextAttemptAt time.Time       `db:"next_attempt_at"`
+	DeliveredAt   *time.Time      `db:"delivered_at"`
+}
+
+// PendingDelivery - доставка, взятая в работу, вместе с адресом и ключом вебхука
+type PendingDelivery struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// CategoryMerge - запись о слиянии категорий
+type CategoryMerge struct {
+	ID         int64
+	Target     Category
+	SourceIDs  []int64
+	MovedTasks int64
+	Actor      string
+	CreatedAt  time.Time
+}
+
+// TaskCounts - количество задач по статусам
+type TaskCounts struct {
+	TODO       int64
+	InProgress int64
+	Done       int64
+	Archived   int64
+}
+
+func (c *TaskCounts) Add(st TaskStatus, n int64) {
+	switch st {
+	case TODO:
+		c.TODO += n
+	case InProgress:
+		c.InProgress += n
+	case Done:
+		c.Done += n
+	case Archived:
+		c.Archived += n
+	}
+}
+
+func (c TaskCounts) Total() int64 {
+	return c.TODO + c.InProgress + c.Done + c.Archived
+}
+
+// CategoryNode - категория с вложенными категориями
+type CategoryNode struct {
+	Category
+	Children []CategoryNode
+}
+
+type Tag struct {
 	ID        int64     `db:"id"`
 	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
@@ -0,0 +1,86 @@
+package core
+
+import "time"
+
+type Option func(*Service)
+
+// WithSubtaskRollup запрещает переводить задачу в Done, пока у неё есть открытые подзадачи
+func WithSubtaskRollup(enabled bool) Option {
+	return func(s *Service) {
+		s.subtaskRollup = enabled
+	}
+}
+
+// WithIdempotencyTTL задаёт, сколько хранится ответ на запрос с ключом идемпотентности
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		if ttl > 0 {
+			s.idempotencyTTL = ttl
+		}
+	}
+}
+
+// WithWatchPollInterval задаёт, как часто WatchTasks перечитывает журнал без сигнала от сервиса
+func WithWatchPollInterval(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.watchPoll = d
+		}
+	}
+}
+
+// WithOutboxMaxBackoff ограничивает паузу перед повторной отправкой события outbox
+func WithOutboxMaxBackoff(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.outboxMaxBackoff = d
+		}
+	}
+}
+
+// WithOutboxLease задаёт, на сколько relay берёт события в аренду
+func WithOutboxLease(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.outboxLease = d
+		}
+	}
+}
+
+// WithWebhookRetry задаёт, сколько раз пробовать доставить вебхук и максимальную паузу между попытками
+func WithWebhookRetry(maxAttempts int, maxBackoff time.Duration) Option {
+	return func(s *Service) {
+		if maxAttempts > 0 {
+			s.webhookMaxAttempts = maxAttempts
+		}
+		if maxBackoff > 0 {
+			s.webhookMaxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithWebhookTimeout задаёт, сколько ждать ответа получателя вебхука; от него зависит аренда доставок
+func WithWebhookTimeout(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.webhookTimeout = d
+		}
+	}
+}
+
+// WithBootstrapAPIKey принимает key как API-ключ сервиса без пользователя, чтобы
+// завести первого пользователя и выпустить ему ключ; key проверяется CheckBootstrapAPIKey
+func WithBootstrapAPIKey(key string) Option {
+	return func(s *Service) {
+		if key != "" {
+			s.bootstrapKeyHash = hashAPIKey(key)
+		}
+	}
+}
+
+// WithWorkflow ограничивает переходы статусов задачи таблицей w; nil => любые переходы
+func WithWorkflow(w Workflow) Option {
+	return func(s *Service) {
+		s.workflow = w
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outbox
+
+// Типы доменных событий outbox
+const (
+	OutboxTaskCreated       = "task.created"
+	OutboxTaskUpdated       = "task.updated"
+	OutboxTaskStatusChanged = "task.status_changed"
+	OutboxTaskDeleted       = "task.deleted"
+	OutboxTaskRestored      = "task.restored"
+	OutboxTaskPurged        = "task.purged"
+
+	OutboxCategoryCreated  = "category.created"
+	OutboxCategoryUpdated  = "category.updated"
+	OutboxCategoryMoved    = "category.moved"
+	OutboxCategoryDeleted  = "category.deleted"
+	OutboxCategoryRestored = "category.restored"
+	OutboxCategoryMerged   = "category.merged"
+	OutboxCategoryPurged   = "category.purged"
+)
+
+// outboxTypes - все типы событий, на них можно подписать вебхук
+var outboxTypes = map[string]bool{
+	OutboxTaskCreated:       true,
+	OutboxTaskUpdated:       true,
+	OutboxTaskStatusChanged: true,
+	OutboxTaskDeleted:       true,
+	OutboxTaskRestored:      true,
+	OutboxTaskPurged:        true,
+
+	OutboxCategoryCreated:  true,
+	OutboxCategoryUpdated:  true,
+	OutboxCategoryMoved:    true,
+	OutboxCategoryDeleted:  true,
+	OutboxCategoryRestored: true,
+	OutboxCategoryMerged:   true,
+	OutboxCategoryPurged:   true,
+}
+
+const (
+	// DefaultOutboxBatchSize - сколько событий relay забирает за один проход
+	DefaultOutboxBatchSize = 100
+	// DefaultOutboxMaxBackoff - максимальная пауза перед повторной отправкой
+	DefaultOutboxMaxBackoff = 10 * time.Minute
+	// DefaultOutboxLease - на сколько откладываются взятые relay события;
+	// отправка всего прохода должна в неё укладываться
+	DefaultOutboxLease = time.Minute
+
+	minRetryBackoff = time.Second
+	// maxLastErrorLen - ограничение длины last_error в байтах
+	maxLastErrorLen = 1024
+)
+
+// TaskPayload - событие задачи: изменённые поля, как в истории задачи
+type TaskPayload struct {
+	TaskID  int64         `json:"task_id"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// TaskStatusPayload - смена статуса задачи, значения как в FieldChange
+type TaskStatusPayload struct {
+	TaskID int64           `json:"task_id"`
+	From   json.RawMessage `json:"from"`
+	To     json.RawMessage `json:"to"`
+}
+
+// CategoryPayload - снимок категории после изменения; у удаления только id
+type CategoryPayload struct {
+	CategoryID  int64  `json:"category_id"`
+	ParentID    *int64 `json:"parent_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Position    int32  `json:"position,omitempty"`
+}
+
+func NewCategoryPayload(c Category) CategoryPayload {
+	return CategoryPayload{
+		CategoryID:  c.ID,
+		ParentID:    c.ParentID,
+		Name:        c.Name,
+		Description: c.Description,
+		Color:       c.Color,
+		Icon:        c.Icon,
+		Position:    c.Position,
+	}
+}
+
+// CategoryMergedPayload - слияние source категорий в target
+type CategoryMergedPayload struct {
+	MergeID    int64   `json:"merge_id"`
+	TargetID   int64   `json:"target_id"`
+	SourceIDs  []int64 `json:"source_ids"`
+	MovedTasks int64   `json:"moved_tasks"`
+}
+
+// AggregateKey - ключ агрегата события, "task:42" или "category:7": задачи и
+// категории с одним id различаются. Порядок событий сохраняется в пределах ключа
+func (e OutboxEvent) AggregateKey() string {
+	kind, _, _ := strings.Cut(e.Type, ".")
+	return kind + ":" + strconv.FormatInt(e.AggregateID, 10)
+}
+
+// RelayResult - итог одного прохода relay
+type RelayResult struct {
+	Published int
+	Failed    int
+}
+
+// RelayOutbox за один проход отправляет через pub до limit готовых событий.
+// События берутся в аренду, поэтому несколько экземпляров сервиса не отправляют
+// одно событие одновременно, а блокировки в БД не держатся, пока pub ходит в сеть.
+// Неудачное событие откладывается с растущей паузой, а остальные события того же
+// агрегата ждут его - и в этом проходе, и в следующих, - чтобы сохранить порядок.
+// Событие может быть доставлено повторно, если pub отработал, а отметка об
+// отправке не сохранилась
+func (s *Service) RelayOutbox(ctx context.Context, pub Publisher, limit int) (RelayResult, error) {
+	if limit <= 0 {
+		limit = DefaultOutboxBatchSize
+	}
+
+	events, err := s.db.ClaimOutbox(ctx, limit, s.outboxLease)
+	if err != nil {
+		return RelayResult{}, err
+	}
+
+	// после аренды события может взять другой relay, отправка должна успеть до неё
+	pubCtx, cancel := context.WithTimeout(ctx, s.outboxLease)
+	defer cancel()
+
+	var (
+		res      RelayResult
+		blocked  = make(map[string]bool)
+		released []int64
+	)
+	for _, e := range events {
+		key := e.AggregateKey()
+		if blocked[key] {
+			released = append(released, e.ID)
+			continue
+		}
+
+		if err := pub.Publish(pubCtx, e); err != nil {
+			blocked[key] = true
+			res.Failed++
+			if err := s.db.MarkOutboxFailed(ctx, e.ID, backoff(e.Attempts, s.outboxMaxBackoff), lastError(err.Error())); err != nil {
+				return RelayResult{}, err
+			}
+			continue
+		}
+
+		res.Published++
+		if err := s.db.MarkOutboxPublished(ctx, e.ID); err != nil {
+			return RelayResult{}, err
+		}
+	}
+
+	// события за неудачным снова в работе, но ClaimOutbox не отдаст их раньше него
+	if err := s.db.ReleaseOutbox(ctx, released); err != nil {
+		return RelayResult{}, err
+	}
+	return res, nil
+}
+
+// PurgeOutbox удаляет события, опубликованные раньше before;
+// unpublished => и неопубликованные, созданные раньше before
+func (s *Service) PurgeOutbox(ctx context.Context, before time.Time, unpublished bool) (int64, error) {
+	return s.db.PurgeOutbox(ctx, before, unpublished)
+}
+
+// Helpers
+
+// backoff - пауза перед следующей попыткой после attempts неудачных: 1s, 2s, 4s, ... но не больше limit
+func backoff(attempts int, limit time.Duration) time.Duration {
+	d := minRetryBackoff
+	for i := 0; i < attempts && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// lastError обрезает текст ошибки для last_error
+func lastError(msg string) string {
+	if len(msg) > maxLastErrorLen {
+		// обрезка не должна разрезать многобайтный символ: postgres не примет такую строку
+		msg = strings.ToValidUTF8(msg[:maxLastErrorLen], "")
+	}
+	return msg
+}
@@ -0,0 +1,196 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"task-manager-microservice/tasks/adapters/publisher"
+	"task-manager-microservice/tasks/core"
+)
+
+// outboxDB повторяет семантику ClaimOutbox из adapters/db на часах now:
+// событие не отдаётся, пока не опубликовано более раннее событие его агрегата
+type outboxDB struct {
+	core.DB
+
+	now     time.Time
+	events  []core.OutboxEvent
+	next    map[int64]time.Time
+	done    map[int64]bool
+	retries []time.Duration
+}
+
+func newOutboxDB(events ...core.OutboxEvent) *outboxDB {
+	db := &outboxDB{
+		now:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		next: map[int64]time.Time{},
+		done: map[int64]bool{},
+	}
+	for _, e := range events {
+		db.events = append(db.events, e)
+		db.next[e.ID] = db.now
+	}
+	return db
+}
+
+func (db *outboxDB) ClaimOutbox(_ context.Context, limit int, lease time.Duration) ([]core.OutboxEvent, error) {
+	var claimed []core.OutboxEvent
+	deferred := map[string]bool{}
+	for _, e := range db.events {
+		if db.done[e.ID] {
+			continue
+		}
+		if db.next[e.ID].After(db.now) {
+			deferred[e.AggregateKey()] = true
+			continue
+		}
+		if deferred[e.AggregateKey()] || len(claimed) == limit {
+			continue
+		}
+		claimed = append(claimed, e)
+	}
+	for _, e := range claimed {
+		db.next[e.ID] = db.now.Add(lease)
+	}
+	return claimed, nil
+}
+
+func (db *outboxDB) ReleaseOutbox(_ context.Context, ids []int64) error {
+	for _, id := range ids {
+		db.next[id] = db.now
+	}
+	return nil
+}
+
+func (db *outboxDB) MarkOutboxPublished(_ context.Context, id int64) error {
+	db.done[id] = true
+	return nil
+}
+
+func (db *outboxDB) MarkOutboxFailed(_ context.Context, id int64, retryAfter time.Duration, _ string) error {
+	for i := range db.events {
+		if db.events[i].ID == id {
+			db.events[i].Attempts++
+		}
+	}
+	db.next[id] = db.now.Add(retryAfter)
+	db.retries = append(db.retries, retryAfter)
+	return nil
+}
+
+func event(id int64, typ string, aggregate int64) core.OutboxEvent {
+	return core.OutboxEvent{ID: id, Type: typ, AggregateID: aggregate}
+}
+
+func publishedIDs(pub *publisher.Memory) []int64 {
+	var ids []int64
+	for _, e := range pub.Events() {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func relay(t *testing.T, svc *core.Service, pub core.Publisher, want core.RelayResult) {
+	t.Helper()
+
+	res, err := svc.RelayOutbox(context.Background(), pub, 10)
+	if err != nil {
+		t.Fatalf("RelayOutbox: %v", err)
+	}
+	if res != want {
+		t.Fatalf("RelayOutbox = %+v, want %+v", res, want)
+	}
+}
+
+func TestRelayOutboxKeepsAggregateOrder(t *testing.T) {
+	db := newOutboxDB(
+		event(1, core.OutboxTaskCreated, 1),
+		event(2, core.OutboxTaskCreated, 2),
+		event(3, core.OutboxTaskUpdated, 1),
+		event(4, core.OutboxCategoryCreated, 1), // другой агрегат с тем же id
+		event(5, core.OutboxTaskStatusChanged, 1),
+	)
+	svc := core.NewService(db)
+
+	pub := publisher.NewMemory()
+	failed := false
+	pub.Fail = func(e core.OutboxEvent) error {
+		if e.ID == 1 && !failed {
+			failed = true
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+
+	// 1 не отправлено, 3 и 5 ждут его; задача 2 и категория 1 не ждут
+	relay(t, svc, pub, core.RelayResult{Published: 2, Failed: 1})
+	if got, want := publishedIDs(pub), []int64{2, 4}; !slices.Equal(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+
+	// пока пауза 1 не прошла, следующий проход не отдаёт 3 и 5
+	relay(t, svc, pub, core.RelayResult{})
+
+	db.now = db.now.Add(time.Second)
+	relay(t, svc, pub, core.RelayResult{Published: 3})
+	if got, want := publishedIDs(pub), []int64{2, 4, 1, 3, 5}; !slices.Equal(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+
+	relay(t, svc, pub, core.RelayResult{})
+}
+
+func TestRelayOutboxRetriesWithBackoff(t *testing.T) {
+	db := newOutboxDB(event(1, core.OutboxTaskCreated, 1), event(2, core.OutboxTaskUpdated, 1))
+	svc := core.NewService(db, core.WithOutboxMaxBackoff(5*time.Second))
+
+	pub := publisher.NewMemory()
+	attempts := 0
+	pub.Fail = func(e core.OutboxEvent) error {
+		if e.ID != 1 {
+			return nil
+		}
+		attempts++
+		if attempts <= 4 {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+
+	for range 4 {
+		relay(t, svc, pub, core.RelayResult{Failed: 1})
+		db.now = db.now.Add(10 * time.Second)
+	}
+	// пауза растёт вдвое и упирается в максимум
+	if want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}; !slices.Equal(db.retries, want) {
+		t.Fatalf("retries %v, want %v", db.retries, want)
+	}
+	if got := publishedIDs(pub); len(got) != 0 {
+		t.Fatalf("published %v before the failed event", got)
+	}
+
+	relay(t, svc, pub, core.RelayResult{Published: 2})
+	if got, want := publishedIDs(pub), []int64{1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+}
+
+func TestRelayOutboxLeaseHidesClaimedEvents(t *testing.T) {
+	db := newOutboxDB(event(1, core.OutboxTaskCreated, 1))
+
+	// другой relay взял событие и ещё не отметил его
+	if _, err := db.ClaimOutbox(context.Background(), 10, core.DefaultOutboxLease); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := core.NewService(db)
+	pub := publisher.NewMemory()
+	relay(t, svc, pub, core.RelayResult{})
+
+	// аренда истекла: тот relay упал, событие отправляется снова
+	db.now = db.now.Add(core.DefaultOutboxLease)
+	relay(t, svc, pub, core.RelayResult{Published: 1})
+}
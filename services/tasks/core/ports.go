@@ -1,26 +1,201 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type CategoriesDB interface {
-	CreateCategory(ctx context.Context, name string) (Category, error)
+	CreateCategory(ctx context.Context, in NewCategory) (Category, error)
 	GetCategory(ctx context.Context, id int64) (Category, error)
-	ListCategories(ctx context.Context) ([]Category, error)
-	UpdateCategory(ctx context.Context, id int64, name string) (Category, error)
-	DeleteCategory(ctx context.Context, id int64) error
+	// LockCategory - GetCategory, которую до конца транзакции нельзя удалить или переместить
+	LockCategory(ctx context.Context, id int64) (Category, error)
+	// LockCategoryForUpdate - GetCategory с блокировкой категории на запись до конца транзакции
+	LockCategoryForUpdate(ctx context.Context, id int64) (Category, error)
+	ListCategories(ctx context.Context, f ListCategoriesFilter) ([]Category, error)
+	UpdateCategory(ctx context.Context, c Category) (Category, error)
+	DeleteCategory(ctx context.Context, id int64, d CategoryDeletion) error
+	CategoryAncestors(ctx context.Context, id int64) ([]int64, error)
+	CategorySubtree(ctx context.Context, rootID int64) ([]Category, error)
+	// LockCategoryTree до конца транзакции не даёт другим переносам менять дерево категорий
+	LockCategoryTree(ctx context.Context) error
+	MoveCategory(ctx context.Context, id int64, parentID *int64) (Category, error)
+	CategoryTaskCounts(ctx context.Context, ids []int64) (map[int64]TaskCounts, error)
+	MergeCategories(ctx context.Context, targetID int64, sourceIDs []int64) (CategoryMerge, error)
 }
 
 type TasksDB interface {
-	CreateTask(ctx context.Context, categoryID *int64, name, description string) (Task, error)
+	CreateTask(ctx context.Context, in NewTask) (Task, error)
 	GetTask(ctx context.Context, id int64) (Task, error)
+	// LockTask - GetTask с блокировкой задачи на запись до конца транзакции
+	LockTask(ctx context.Context, id int64) (Task, error)
 	ListTasks(ctx context.Context, f ListTasksFilter) ([]Task, error)
+	CountTasks(ctx context.Context, f ListTasksFilter) (int64, error)
+	SearchTasks(ctx context.Context, f ListTasksFilter) ([]TaskHit, error)
 	UpdateTask(ctx context.Context, t Task) (Task, error)
-	DeleteTask(ctx context.Context, id int64) error
+	DeleteTask(ctx context.Context, id, version int64) error
+	// AssignTask меняет исполнителя, nil => снять; version != 0 должна совпасть с текущей
+	AssignTask(ctx context.Context, id int64, assigneeID *int64, version int64) (Task, error)
+
+	// пакетные операции в одной транзакции, atomic => первая ошибка откатывает всё
+	BatchCreateTasks(ctx context.Context, items []NewTask, atomic bool) ([]BatchResult, error)
+	BatchUpdateTasks(ctx context.Context, tasks []Task, atomic bool) ([]BatchResult, error)
+	BatchDeleteTasks(ctx context.Context, ids []int64, atomic bool) ([]BatchResult, error)
+
+	// иерархия подзадач
+	// LockTaskTree до конца транзакции не даёт другим переносам менять дерево подзадач
+	LockTaskTree(ctx context.Context) error
+	TaskAncestors(ctx context.Context, id int64) ([]int64, error)
+	TaskSubtree(ctx context.Context, rootID int64) ([]Task, error)
+	CountOpenSubtasks(ctx context.Context, parentID int64) (int, error)
+}
+
+type UsersDB interface {
+	CreateUser(ctx context.Context, in NewUser) (User, error)
+	GetUser(ctx context.Context, id int64) (User, error)
+	// LockUser - GetUser, которого до конца транзакции нельзя удалить
+	LockUser(ctx context.Context, id int64) (User, error)
+	ListUsers(ctx context.Context, f ListUsersFilter) ([]User, error)
+	// DeleteUser снимает пользователя с задач, это изменение пишется в их историю
+	DeleteUser(ctx context.Context, id int64) error
+}
+
+type APIKeysDB interface {
+	CreateAPIKey(ctx context.Context, in NewAPIKey, prefix, hash string) (APIKey, error)
+	GetAPIKey(ctx context.Context, id int64) (APIKey, error)
+	ListAPIKeys(ctx context.Context, f ListAPIKeysFilter) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	// AuthenticateAPIKey ищет неотозванный ключ по хешу и отмечает его использование
+	AuthenticateAPIKey(ctx context.Context, hash string) (APIKey, error)
+}
+
+type AccessDB interface {
+	// CategoryRole - старшая роль пользователя в категории и её предках, 0 => роли нет.
+	// Категории в корзине тоже учитываются
+	CategoryRole(ctx context.Context, categoryID, userID int64) (Role, error)
+	// ReadableCategoryIDs - категории с ролью пользователя и все их потомки
+	ReadableCategoryIDs(ctx context.Context, userID int64) ([]int64, error)
+	SetCategoryMember(ctx context.Context, m CategoryMember) (CategoryMember, error)
+	RemoveCategoryMember(ctx context.Context, categoryID, userID int64) error
+	ListCategoryMembers(ctx context.Context, categoryID int64) ([]CategoryMember, error)
+	// CountCategoryOwners - сколько владельцев у категории с учётом предков,
+	// не считая роли exceptUserID, выданной прямо на категорию
+	CountCategoryOwners(ctx context.Context, categoryID, exceptUserID int64) (int, error)
+}
+
+type TagsDB interface {
+	CreateTag(ctx context.Context, name string) (Tag, error)
+	GetTag(ctx context.Context, id int64) (Tag, error)
+	ListTags(ctx context.Context, f ListTagsFilter) ([]Tag, error)
+	RenameTag(ctx context.Context, id int64, name string) (Tag, error)
+	DeleteTag(ctx context.Context, id int64) error
+	MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (Tag, error)
+
+	AddTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error
+	RemoveTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error
+	// TaskTags возвращает теги задач: task id => теги
+	TaskTags(ctx context.Context, taskIDs []int64) (map[int64][]Tag, error)
+}
+
+type DependenciesDB interface {
+	// LockDependencyGraph до конца транзакции не даёт другим вызовам добавлять связи
+	LockDependencyGraph(ctx context.Context) error
+	AddDependency(ctx context.Context, taskID, blockedByID int64) error
+	RemoveDependency(ctx context.Context, taskID, blockedByID int64) error
+	ListBlockers(ctx context.Context, taskID int64) ([]Task, error)
+	ListBlocked(ctx context.Context, taskID int64) ([]Task, error)
+	CountOpenBlockers(ctx context.Context, taskID int64) (int, error)
+	DependencyPathExists(ctx context.Context, from, to int64) (bool, error)
+}
+
+type HistoryDB interface {
+	ListTaskEvents(ctx context.Context, f ListTaskEventsFilter) ([]TaskEvent, error)
+
+	// журнал всех задач для WatchTasks, в порядке коммита. SequenceTaskEvents
+	// упорядочивает новые события, без этого TaskEventsAfter их не видит
+	SequenceTaskEvents(ctx context.Context, limit int) (int, error)
+	TaskEventsAfter(ctx context.Context, afterID int64, limit int) ([]TaskEvent, error)
+	LastTaskEventID(ctx context.Context) (int64, error)
+	// TasksByIDs возвращает и задачи из корзины
+	TasksByIDs(ctx context.Context, ids []int64) ([]Task, error)
+}
+
+type TrashDB interface {
+	RestoreTask(ctx context.Context, id int64) (Task, error)
+	RestoreCategory(ctx context.Context, id int64) (Category, error)
+	ListDeletedTasks(ctx context.Context, f ListDeletedFilter) ([]Task, error)
+	ListDeletedCategories(ctx context.Context, f ListDeletedFilter) ([]Category, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (PurgeResult, error)
+}
+
+// UnitOfWork объединяет несколько вызовов DB в одну транзакцию
+type UnitOfWork interface {
+	// WithTx выполняет fn в транзакции: вызовы DB с ctx из fn работают в ней,
+	// ошибка fn откатывает всё. Вне fn Lock* методы блокировку не удерживают
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type IdempotencyDB interface {
+	// ClaimIdempotencyKey занимает ключ в текущей транзакции; found => ключ уже занят, возвращается его запись
+	ClaimIdempotencyKey(ctx context.Context, k IdempotencyKey) (rec IdempotencyKey, found bool, err error)
+	SaveIdempotencyResponse(ctx context.Context, scope, key string, response []byte) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type OutboxDB interface {
+	// ClaimOutbox берёт готовые к отправке события в порядке id и откладывает их на lease:
+	// если результат не записан, событие вернётся в работу после lease. События агрегата,
+	// у которого есть более раннее отложенное событие, не берутся
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+	// ReleaseOutbox возвращает взятые, но не отправленные события в работу
+	ReleaseOutbox(ctx context.Context, ids []int64) error
+	MarkOutboxPublished(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, retryAfter time.Duration, reason string) error
+	PurgeOutbox(ctx context.Context, before time.Time, unpublished bool) (int64, error)
+}
+
+// Publisher доставляет события outbox во внешний брокер. Ошибка => событие
+// будет отправлено повторно, доставка не меньше одного раза
+type Publisher interface {
+	Publish(ctx context.Context, e OutboxEvent) error
+}
+
+type WebhooksDB interface {
+	CreateWebhook(ctx context.Context, in NewWebhook) (Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
+	ListWebhooks(ctx context.Context, f ListWebhooksFilter) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	ListDeliveries(ctx context.Context, f ListDeliveriesFilter) ([]WebhookDelivery, error)
+	// ClaimDeliveries берёт готовые к отправке доставки и откладывает их на lease:
+	// если результат не записан, доставка вернётся в работу после lease
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]PendingDelivery, error)
+	MarkDeliveryDelivered(ctx context.Context, id int64, code int) error
+	// MarkDeliveryFailed записывает неудачную попытку; dead => доставка больше не отправляется
+	MarkDeliveryFailed(ctx context.Context, id int64, code int, retryAfter time.Duration, reason string, dead bool) error
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookSender отправляет доставку получателю. code - HTTP-код ответа,
+// err - ошибка, из-за которой ответа нет
+type WebhookSender interface {
+	Send(ctx context.Context, d PendingDelivery) (code int, err error)
 }
 
 type DB interface {
+	UnitOfWork
 	CategoriesDB
 	TasksDB
+	UsersDB
+	APIKeysDB
+	AccessDB
+	TagsDB
+	DependenciesDB
+	HistoryDB
+	TrashDB
+	IdempotencyDB
+	OutboxDB
+	WebhooksDB
 
 	Ping(ctx context.Context) error
 }
@@ -2,147 +2,717 @@ package core
 
 import (
 	"context"
+	"regexp"
 	"strings"
+	"time"
 )
 
 type Service struct {
 	db DB
+
+	subtaskRollup  bool
+	workflow       Workflow
+	idempotencyTTL time.Duration
+
+	// подписчики WatchTasks
+	watchers  *broadcaster
+	watchPoll time.Duration
+
+	// отложенная повторная отправка событий outbox и аренда взятых relay
+	outboxMaxBackoff time.Duration
+	outboxLease      time.Duration
+
+	// sha256 ключа из конфига; пусто => такого ключа нет
+	bootstrapKeyHash string
+
+	// таймаут и повторные доставки вебхуков
+	webhookTimeout     time.Duration
+	webhookMaxAttempts int
+	webhookMaxBackoff  time.Duration
 }
 
-func NewService(db DB) *Service {
-	return &Service{
-		db: db,
+func NewService(db DB, opts ...Option) *Service {
+	s := &Service{
+		db:             db,
+		workflow:       DefaultWorkflow(),
+		idempotencyTTL: DefaultIdempotencyTTL,
+		watchers:       newBroadcaster(),
+		watchPoll:      DefaultWatchPollInterval,
+
+		outboxMaxBackoff: DefaultOutboxMaxBackoff,
+		outboxLease:      DefaultOutboxLease,
+
+		webhookTimeout:     DefaultWebhookTimeout,
+		webhookMaxAttempts: DefaultWebhookMaxAttempts,
+		webhookMaxBackoff:  DefaultWebhookMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
+// maxQueryLen - ограничение длины поискового запроса в байтах
+const maxQueryLen = 256
+
 func isValidStatus(st TaskStatus) bool {
 	return st >= TODO && st <= Archived
 }
 
+func isValidPriority(p TaskPriority) bool {
+	return p >= PriorityLow && p <= PriorityUrgent
+}
+
 func (s *Service) Ping(ctx context.Context) error {
 	return s.db.Ping(ctx)
 }
 
 // Categories
 
-func (s *Service) CreateCategory(ctx context.Context, name string) (Category, error) {
-	if strings.TrimSpace(name) == "" {
+type NewCategory struct {
+	Name        string
+	ParentID    *int64
+	Description string
+	Color       string
+	Icon        string
+	Position    int32
+}
+
+type CategoryPatch struct {
+	Name        *string
+	Description *string // пустая строка => очистить
+	Color       *string // пустая строка => без цвета
+	Icon        *string // пустая строка => без иконки
+	Position    *int32
+}
+
+// maxIconLen - ограничение длины имени иконки в байтах
+const maxIconLen = 64
+
+var colorRe = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// normalizeColor приводит цвет к #rrggbb, пустая строка допустима
+func normalizeColor(c string) (string, bool) {
+	c = strings.ToLower(strings.TrimSpace(c))
+	return c, c == "" || colorRe.MatchString(c)
+}
+
+// CreateCategory создаёт категорию; с ключом идемпотентности в ctx повтор запроса вернёт ту же категорию
+func (s *Service) CreateCategory(ctx context.Context, in NewCategory) (Category, error) {
+	return idempotent(ctx, s, scopeCreateCategory, in, func(ctx context.Context) (Category, error) {
+		return s.createCategory(ctx, in)
+	})
+}
+
+func (s *Service) createCategory(ctx context.Context, in NewCategory) (Category, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return Category{}, ErrCategoryInvalidArgs
+	}
+	if err := requireUser(ctx); err != nil {
+		return Category{}, err
+	}
+
+	var ok bool
+	if in.Color, ok = normalizeColor(in.Color); !ok {
+		return Category{}, ErrCategoryInvalidArgs
+	}
+	in.Icon = strings.TrimSpace(in.Icon)
+	if len(in.Icon) > maxIconLen {
 		return Category{}, ErrCategoryInvalidArgs
 	}
-	return s.db.CreateCategory(ctx, name)
+
+	var c Category
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if in.ParentID != nil {
+			if *in.ParentID <= 0 {
+				return ErrCategoryInvalidArgs
+			}
+			// новая категория ещё не в дереве, цикл невозможен
+			if _, err := s.db.GetCategory(ctx, *in.ParentID); err != nil {
+				return err
+			}
+			if err := s.requireCategory(ctx, *in.ParentID, RoleEditor); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if c, err = s.db.CreateCategory(ctx, in); err != nil {
+			return err
+		}
+
+		// автор становится владельцем, даже если уже редактор родителя
+		if uid, ok := accessUser(ctx); ok {
+			_, err = s.db.SetCategoryMember(ctx, CategoryMember{CategoryID: c.ID, UserID: uid, Role: RoleOwner})
+		}
+		return err
+	})
+	if err != nil {
+		return Category{}, err
+	}
+	return c, nil
 }
 
 func (s *Service) GetCategory(ctx context.Context, id int64) (Category, error) {
 	if id <= 0 {
 		return Category{}, ErrCategoryInvalidArgs
 	}
-	return s.db.GetCategory(ctx, id)
+
+	c, err := s.db.GetCategory(ctx, id)
+	if err != nil {
+		return Category{}, err
+	}
+	if err := s.requireCategory(ctx, id, RoleViewer); err != nil {
+		return Category{}, err
+	}
+	return c, nil
 }
 
-func (s *Service) ListCategories(ctx context.Context) ([]Category, error) {
-	return s.db.ListCategories(ctx)
+func (s *Service) ListCategories(ctx context.Context, f ListCategoriesFilter) (CategoryPage, error) {
+	if f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return CategoryPage{}, ErrCategoryInvalidArgs
+	}
+
+	f.ReadableBy = readableBy(ctx)
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListCategories(ctx, f)
+	if err != nil {
+		return CategoryPage{}, err
+	}
+
+	page := CategoryPage{Categories: items}
+	if len(items) > size {
+		page.Categories = items[:size]
+		last := page.Categories[size-1]
+		page.Next = &CategoryCursor{Position: last.Position, Name: last.Name, ID: last.ID}
+	}
+
+	if f.WithCounts {
+		ids := make([]int64, 0, len(page.Categories))
+		for _, c := range page.Categories {
+			ids = append(ids, c.ID)
+		}
+		if page.Counts, err = s.db.CategoryTaskCounts(ctx, ids); err != nil {
+			return CategoryPage{}, err
+		}
+	}
+	return page, nil
 }
 
-func (s *Service) UpdateCategory(ctx context.Context, id int64, name string) (Category, error) {
-	if id <= 0 || strings.TrimSpace(name) == "" {
+func (s *Service) PatchCategory(ctx context.Context, id int64, p CategoryPatch) (Category, error) {
+	if id <= 0 {
 		return Category{}, ErrCategoryInvalidArgs
 	}
-	return s.db.UpdateCategory(ctx, id, name)
+	if p.Name == nil && p.Description == nil && p.Color == nil && p.Icon == nil && p.Position == nil {
+		return Category{}, ErrCategoryInvalidArgs
+	}
+
+	var updated Category
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// чтение и запись под одной блокировкой, иначе параллельный patch потеряется
+		cur, err := s.db.LockCategoryForUpdate(ctx, id)
+		if err != nil {
+			return err // ErrCategoryNotFound -> NotFound
+		}
+		if err := s.requireCategory(ctx, id, RoleOwner); err != nil {
+			return err
+		}
+		if err := applyCategoryPatch(&cur, p); err != nil {
+			return err
+		}
+
+		updated, err = s.db.UpdateCategory(ctx, cur)
+		return err
+	})
+	if err != nil {
+		return Category{}, err
+	}
+	return updated, nil
+}
+
+// applyCategoryPatch применяет заданные поля patch к категории
+func applyCategoryPatch(cur *Category, p CategoryPatch) error {
+	if p.Name != nil {
+		name := strings.TrimSpace(*p.Name)
+		if name == "" {
+			return ErrCategoryInvalidArgs
+		}
+		cur.Name = name
+	}
+
+	if p.Description != nil {
+		cur.Description = strings.TrimSpace(*p.Description)
+	}
+
+	if p.Color != nil {
+		color, ok := normalizeColor(*p.Color)
+		if !ok {
+			return ErrCategoryInvalidArgs
+		}
+		cur.Color = color
+	}
+
+	if p.Icon != nil {
+		icon := strings.TrimSpace(*p.Icon)
+		if len(icon) > maxIconLen {
+			return ErrCategoryInvalidArgs
+		}
+		cur.Icon = icon
+	}
+
+	if p.Position != nil {
+		cur.Position = *p.Position
+	}
+	return nil
+}
+
+// CategoryDeleteStrategy - что делать с задачами удаляемой категории
+type CategoryDeleteStrategy int16
+
+const (
+	DeleteOrphan         CategoryDeleteStrategy = 0 // задачи остаются без категории
+	DeleteReassign       CategoryDeleteStrategy = 1 // задачи переезжают в TargetID
+	DeleteTasks          CategoryDeleteStrategy = 2 // задачи уходят в корзину вместе с категорией
+	DeleteFailIfNotEmpty CategoryDeleteStrategy = 3 // ErrCategoryNotEmpty, если задачи есть
+)
+
+type CategoryDeletion struct {
+	Strategy CategoryDeleteStrategy
+	TargetID int64 // только для DeleteReassign
 }
 
-func (s *Service) DeleteCategory(ctx context.Context, id int64) error {
+func (s *Service) DeleteCategory(ctx context.Context, id int64, d CategoryDeletion) error {
 	if id <= 0 {
 		return ErrCategoryInvalidArgs
 	}
-	return s.db.DeleteCategory(ctx, id)
+
+	switch d.Strategy {
+	case DeleteReassign:
+		if d.TargetID <= 0 || d.TargetID == id {
+			return ErrCategoryInvalidArgs
+		}
+	case DeleteOrphan, DeleteTasks, DeleteFailIfNotEmpty:
+		if d.TargetID != 0 {
+			return ErrCategoryInvalidArgs
+		}
+	default:
+		return ErrCategoryInvalidArgs
+	}
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// дочерние категории поднимаются к родителю удаляемой, это перенос
+		if err := s.db.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		if _, err := s.db.LockCategoryForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if err := s.requireCategory(ctx, id, RoleOwner); err != nil {
+			return err
+		}
+		if d.Strategy == DeleteReassign {
+			if _, err := s.db.LockCategory(ctx, d.TargetID); err != nil {
+				return err
+			}
+			if err := s.requireCategory(ctx, d.TargetID, RoleEditor); err != nil {
+				return err
+			}
+		}
+
+		return s.db.DeleteCategory(ctx, id, d)
+	})
+	if err != nil {
+		return err
+	}
+	// задачи категории переехали или удалены
+	s.notifyWatchers()
+	return nil
 }
 
 // Tasks
 
+type NewTask struct {
+	CategoryID  *int64
+	ParentID    *int64
+	Name        string
+	Description string
+	Priority    TaskPriority
+	DueAt       *time.Time
+	AssigneeID  *int64
+
+	// заполняет сервис: пользователь из ctx
+	CreatorID *int64
+}
+
 type TaskPatch struct {
 	CategoryID  *int64
+	ParentID    *int64 // 0 => сделать задачей верхнего уровня
 	Name        *string
 	Description *string
 	Status      *TaskStatus
+	Priority    *TaskPriority
+	DueAt       *time.Time // нулевое время => снять срок
+
+	// 0 => не проверять, иначе текущая версия задачи должна совпасть
+	ExpectedVersion int64
 }
 
-func (s *Service) CreateTask(ctx context.Context, categoryID *int64, name, description string) (Task, error) {
-	if strings.TrimSpace(name) == "" {
-		return Task{}, ErrTaskInvalidArgs
+func (p TaskPatch) empty() bool {
+	return p.CategoryID == nil && p.Name == nil && p.Description == nil && p.Status == nil && p.Priority == nil && p.DueAt == nil && p.ParentID == nil
+}
+
+// CreateTask создаёт задачу; с ключом идемпотентности в ctx повтор запроса вернёт ту же задачу
+func (s *Service) CreateTask(ctx context.Context, in NewTask) (Task, error) {
+	in.CreatorID = creatorFromContext(ctx)
+	t, err := idempotent(ctx, s, scopeCreateTask, in, func(ctx context.Context) (Task, error) {
+		return s.createTask(ctx, in)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	s.notifyWatchers()
+	return t, nil
+}
+
+func (s *Service) createTask(ctx context.Context, in NewTask) (Task, error) {
+	var t Task
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkNewTask(ctx, in); err != nil {
+			return err
+		}
+
+		var err error
+		t, err = s.db.CreateTask(ctx, in)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// checkNewTask проверяет поля новой задачи, её категорию и родителя
+func (s *Service) checkNewTask(ctx context.Context, in NewTask) error {
+	if strings.TrimSpace(in.Name) == "" {
+		return ErrTaskInvalidArgs
+	}
+	if !isValidPriority(in.Priority) {
+		return ErrTaskInvalidArgs
+	}
+	if in.DueAt != nil && in.DueAt.IsZero() {
+		return ErrTaskInvalidArgs
+	}
+	if err := requireUser(ctx); err != nil {
+		return err
+	}
+
+	if in.CategoryID != nil {
+		if *in.CategoryID <= 0 {
+			return ErrTaskInvalidArgs
+		}
+		if _, err := s.db.LockCategory(ctx, *in.CategoryID); err != nil {
+			return err
+		}
+		if err := s.requireCategory(ctx, *in.CategoryID, RoleEditor); err != nil {
+			return err
+		}
 	}
 
-	if categoryID != nil {
-		if *categoryID <= 0 {
-			return Task{}, ErrTaskInvalidArgs
+	if in.ParentID != nil {
+		if *in.ParentID <= 0 {
+			return ErrTaskInvalidArgs
 		}
-		if _, err := s.db.GetCategory(ctx, *categoryID); err != nil {
-			return Task{}, err
+		// новая задача открыта, значит цикл невозможен, проверяем только родителя
+		if err := s.checkParent(ctx, 0, *in.ParentID, TODO); err != nil {
+			return err
 		}
 	}
 
-	return s.db.CreateTask(ctx, categoryID, name, description)
+	for _, uid := range []*int64{in.AssigneeID, in.CreatorID} {
+		if uid == nil {
+			continue
+		}
+		if *uid <= 0 {
+			return ErrTaskInvalidArgs
+		}
+		if _, err := s.db.LockUser(ctx, *uid); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *Service) GetTask(ctx context.Context, id int64) (Task, error) {
 	if id <= 0 {
 		return Task{}, ErrTaskInvalidArgs
 	}
-	return s.db.GetTask(ctx, id)
+
+	t, err := s.requireTaskID(ctx, id, RoleViewer)
+	if err != nil {
+		return Task{}, err
+	}
+	return s.withTags(ctx, t)
 }
 
-func (s *Service) ListTasks(ctx context.Context, f ListTasksFilter) ([]Task, error) {
+func (s *Service) ListTasks(ctx context.Context, f ListTasksFilter) (TaskPage, error) {
+	f.Query = strings.TrimSpace(f.Query)
+
 	if f.Limit < 0 || f.Offset < 0 {
-		return nil, ErrTaskInvalidArgs
+		return TaskPage{}, ErrTaskInvalidArgs
+	}
+	if err := checkTasksFilter(ctx, &f); err != nil {
+		return TaskPage{}, err
+	}
+	if f.After != nil {
+		// курсор выдан для другой сортировки или смешан с offset
+		if f.After.Sort != f.Sort || f.Offset > 0 || f.After.ID <= 0 {
+			return TaskPage{}, ErrTaskInvalidArgs
+		}
+	}
+
+	var page TaskPage
+
+	if f.WithTotal {
+		total, err := s.db.CountTasks(ctx, f)
+		if err != nil {
+			return TaskPage{}, err
+		}
+		page.Total = &total
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	var ranks []float32
+	if f.Query != "" {
+		hits, err := s.db.SearchTasks(ctx, f)
+		if err != nil {
+			return TaskPage{}, err
+		}
+
+		page.Tasks = make([]Task, 0, len(hits))
+		ranks = make([]float32, 0, len(hits))
+		if f.Highlight {
+			page.Highlights = make(map[int64]string, len(hits))
+		}
+		for i, h := range hits {
+			page.Tasks = append(page.Tasks, h.Task)
+			ranks = append(ranks, h.Rank)
+			if f.Highlight && i < size {
+				page.Highlights[h.ID] = h.Snippet
+			}
+		}
+	} else {
+		items, err := s.db.ListTasks(ctx, f)
+		if err != nil {
+			return TaskPage{}, err
+		}
+		page.Tasks = items
+	}
+
+	if len(page.Tasks) > size {
+		page.Tasks = page.Tasks[:size]
+		last := page.Tasks[size-1]
+		page.Next = &TaskCursor{
+			Sort:      f.Sort,
+			Priority:  last.Priority,
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+		if ranks != nil {
+			page.Next.Rank = ranks[size-1]
+		}
+	}
+
+	if err := s.attachTags(ctx, page.Tasks); err != nil {
+		return TaskPage{}, err
+	}
+	return page, nil
+}
+
+// checkTasksFilter проверяет условия отбора задач, нормализует id тегов
+// и ограничивает выборку задачами, видимыми пользователю из ctx
+func checkTasksFilter(ctx context.Context, f *ListTasksFilter) error {
+	if f.AssignedToMe {
+		uid, ok := UserIDFromContext(ctx)
+		if !ok || f.AssigneeID != nil {
+			return ErrTaskInvalidArgs
+		}
+		f.AssigneeID = &uid
+		f.AssignedToMe = false
+	}
+	if f.AssigneeID != nil && (*f.AssigneeID <= 0 || f.Unassigned) {
+		return ErrTaskInvalidArgs
+	}
+	if f.CreatorID != nil && *f.CreatorID <= 0 {
+		return ErrTaskInvalidArgs
 	}
 	if f.Status != nil && !isValidStatus(*f.Status) {
-		return nil, ErrTaskInvalidArgs
+		return ErrTaskInvalidArgs
+	}
+	if f.Priority != nil && !isValidPriority(*f.Priority) {
+		return ErrTaskInvalidArgs
+	}
+	if f.Sort != SortCreatedAtDesc && f.Sort != SortPriorityDesc && f.Sort != SortRelevance {
+		return ErrTaskInvalidArgs
+	}
+	if f.Query == "" && (f.Sort == SortRelevance || f.Highlight) {
+		return ErrTaskInvalidArgs
+	}
+	if len(f.Query) > maxQueryLen {
+		return ErrTaskInvalidArgs
 	}
 	if f.CategoryID != nil && *f.CategoryID <= 0 {
-		return nil, ErrTaskInvalidArgs
+		return ErrTaskInvalidArgs
 	}
 	if f.CategoryID != nil && f.WithoutCategory {
-		return nil, ErrTaskInvalidArgs
+		return ErrTaskInvalidArgs
+	}
+	if f.IncludeSubcategories && f.CategoryID == nil {
+		return ErrTaskInvalidArgs
+	}
+	if f.ParentID != nil && *f.ParentID <= 0 {
+		return ErrTaskInvalidArgs
+	}
+	if f.DueBefore != nil && f.DueAfter != nil && !f.DueAfter.Before(*f.DueBefore) {
+		return ErrTaskInvalidArgs
 	}
-	return s.db.ListTasks(ctx, f)
+	var ok bool
+	if f.AnyTagIDs, ok = normalizeIDs(f.AnyTagIDs); !ok {
+		return ErrTaskInvalidArgs
+	}
+	if f.AllTagIDs, ok = normalizeIDs(f.AllTagIDs); !ok {
+		return ErrTaskInvalidArgs
+	}
+
+	// задачи без доступа молча не попадают в выборку
+	f.ReadableBy = readableBy(ctx)
+	return nil
 }
 
 func (s *Service) UpdateTask(ctx context.Context, t Task) (Task, error) {
 	if t.ID <= 0 || strings.TrimSpace(t.Name) == "" {
 		return Task{}, ErrTaskInvalidArgs
 	}
-	if !isValidStatus(t.Status) {
+	if !isValidStatus(t.Status) || !isValidPriority(t.Priority) {
+		return Task{}, ErrTaskInvalidArgs
+	}
+	if t.CategoryID != nil && *t.CategoryID <= 0 {
+		return Task{}, ErrTaskInvalidArgs
+	}
+	if t.ParentID != nil && *t.ParentID <= 0 {
 		return Task{}, ErrTaskInvalidArgs
 	}
 
-	if t.CategoryID != nil {
-		if *t.CategoryID <= 0 {
-			return Task{}, ErrTaskInvalidArgs
+	var updated Task
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// дерево блокируется раньше задачи, иначе перенос, держащий дерево,
+		// и этот вызов ждали бы друг друга по кругу
+		if t.ParentID != nil {
+			if err := s.db.LockTaskTree(ctx); err != nil {
+				return err
+			}
 		}
-		if _, err := s.db.GetCategory(ctx, *t.CategoryID); err != nil {
-			return Task{}, err
+
+		cur, err := s.db.LockTask(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.requireTask(ctx, cur, RoleEditor); err != nil {
+			return err
+		}
+
+		if t.CategoryID != nil {
+			if _, err := s.db.LockCategory(ctx, *t.CategoryID); err != nil {
+				return err
+			}
+			if err := s.requireCategory(ctx, *t.CategoryID, RoleEditor); err != nil {
+				return err
+			}
+		}
+		if t.ParentID != nil {
+			if err := s.checkParent(ctx, t.ID, *t.ParentID, t.Status); err != nil {
+				return err
+			}
+		}
+		if err := s.checkSubtasksDone(ctx, t.ID, t.Status); err != nil {
+			return err
+		}
+		if err := s.checkTransition(cur.Status, t.Status); err != nil {
+			return err
+		}
+		if err := s.checkNotBlocked(ctx, t.ID, cur.Status, t.Status); err != nil {
+			return err
 		}
-	}
 
-	return s.db.UpdateTask(ctx, t)
+		updated, err = s.db.UpdateTask(ctx, t)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	s.notifyWatchers()
+	return s.withTags(ctx, updated)
 }
 
 func (s *Service) PatchTask(ctx context.Context, id int64, p TaskPatch) (Task, error) {
-	if id <= 0 {
-		return Task{}, ErrTaskInvalidArgs
+	var updated Task
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if p.reparents() {
+			if err := s.db.LockTaskTree(ctx); err != nil {
+				return err
+			}
+		}
+		// задача заблокирована до конца транзакции, проверки патча
+		// и запись видят одну и ту же её версию
+		cur, err := s.patchedTask(ctx, id, p)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.db.UpdateTask(ctx, cur)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
 	}
-	if p.CategoryID == nil && p.Name == nil && p.Description == nil && p.Status == nil {
+	s.notifyWatchers()
+	return s.withTags(ctx, updated)
+}
+
+// patchedTask блокирует задачу id и возвращает её с применённым патчем
+func (s *Service) patchedTask(ctx context.Context, id int64, p TaskPatch) (Task, error) {
+	if id <= 0 || p.empty() || p.ExpectedVersion < 0 {
 		return Task{}, ErrTaskInvalidArgs
 	}
 
-	cur, err := s.db.GetTask(ctx, id)
+	cur, err := s.db.LockTask(ctx, id)
 	if err != nil {
 		return Task{}, err // ErrTaskNotFound -> NotFound
 	}
+	if err := s.requireTask(ctx, cur, RoleEditor); err != nil {
+		return Task{}, err
+	}
+	if p.ExpectedVersion != 0 && p.ExpectedVersion != cur.Version {
+		return Task{}, ErrConflict
+	}
+	if err := s.applyPatch(ctx, &cur, p); err != nil {
+		return Task{}, err
+	}
+	return cur, nil
+}
 
+// applyPatch проверяет патч и применяет его к текущей версии задачи
+func (s *Service) applyPatch(ctx context.Context, cur *Task, p TaskPatch) error {
 	if p.Name != nil {
 		name := strings.TrimSpace(*p.Name)
 		if name == "" {
-			return Task{}, ErrTaskInvalidArgs
+			return ErrTaskInvalidArgs
 		}
 		cur.Name = name
 	}
@@ -154,14 +724,37 @@ func (s *Service) PatchTask(ctx context.Context, id int64, p TaskPatch) (Task, e
 
 	if p.Status != nil {
 		if !isValidStatus(*p.Status) {
-			return Task{}, ErrTaskInvalidArgs
+			return ErrTaskInvalidArgs
+		}
+		if err := s.checkTransition(cur.Status, *p.Status); err != nil {
+			return err
+		}
+		if err := s.checkNotBlocked(ctx, cur.ID, cur.Status, *p.Status); err != nil {
+			return err
 		}
 		cur.Status = *p.Status
 	}
 
+	if p.Priority != nil {
+		if !isValidPriority(*p.Priority) {
+			return ErrTaskInvalidArgs
+		}
+		cur.Priority = *p.Priority
+	}
+
+	if p.DueAt != nil {
+		if p.DueAt.IsZero() {
+			// remove due date
+			cur.DueAt = nil
+		} else {
+			due := *p.DueAt
+			cur.DueAt = &due
+		}
+	}
+
 	if p.CategoryID != nil {
 		if *p.CategoryID < 0 {
-			return Task{}, ErrTaskInvalidArgs
+			return ErrTaskInvalidArgs
 		}
 
 		if *p.CategoryID == 0 {
@@ -170,19 +763,58 @@ func (s *Service) PatchTask(ctx context.Context, id int64, p TaskPatch) (Task, e
 		} else {
 			cid := *p.CategoryID
 			// requirement: if category_id set -> check existence -> NotFound
-			if _, err := s.db.GetCategory(ctx, cid); err != nil {
-				return Task{}, err
+			if _, err := s.db.LockCategory(ctx, cid); err != nil {
+				return err
+			}
+			if err := s.requireCategory(ctx, cid, RoleEditor); err != nil {
+				return err
 			}
 			cur.CategoryID = &cid
 		}
 	}
 
-	return s.db.UpdateTask(ctx, cur)
+	if p.ParentID != nil {
+		if *p.ParentID < 0 {
+			return ErrTaskInvalidArgs
+		}
+
+		if *p.ParentID == 0 {
+			// make top-level task
+			cur.ParentID = nil
+		} else {
+			pid := *p.ParentID
+			cur.ParentID = &pid
+		}
+	}
+
+	if cur.ParentID != nil && (p.ParentID != nil || p.Status != nil) {
+		if err := s.checkParent(ctx, cur.ID, *cur.ParentID, cur.Status); err != nil {
+			return err
+		}
+	}
+	if p.Status != nil {
+		if err := s.checkSubtasksDone(ctx, cur.ID, cur.Status); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *Service) DeleteTask(ctx context.Context, id int64) error {
-	if id <= 0 {
+// DeleteTask переносит задачу в корзину, version != 0 должна совпасть с текущей версией
+func (s *Service) DeleteTask(ctx context.Context, id, version int64) error {
+	if id <= 0 || version < 0 {
 		return ErrTaskInvalidArgs
 	}
-	return s.db.DeleteTask(ctx, id)
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.lockTask(ctx, id, RoleEditor); err != nil {
+			return err
+		}
+		return s.db.DeleteTask(ctx, id, version)
+	})
+	if err != nil {
+		return err
+	}
+	s.notifyWatchers()
+	return nil
 }
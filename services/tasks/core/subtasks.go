@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"slices"
+)
+
+// Subtasks
+
+func (s *Service) ListSubtasks(ctx context.Context, parentID int64, limit int, after *TaskCursor) (TaskPage, error) {
+	if parentID <= 0 {
+		return TaskPage{}, ErrTaskInvalidArgs
+	}
+	if _, err := s.requireTaskID(ctx, parentID, RoleViewer); err != nil {
+		return TaskPage{}, err
+	}
+
+	return s.ListTasks(ctx, ListTasksFilter{
+		ParentID: &parentID,
+		After:    after,
+		Limit:    limit,
+	})
+}
+
+// GetTaskTree собирает задачу со всеми вложенными подзадачами
+func (s *Service) GetTaskTree(ctx context.Context, id int64) (TaskNode, error) {
+	if id <= 0 {
+		return TaskNode{}, ErrTaskInvalidArgs
+	}
+
+	tasks, err := s.db.TaskSubtree(ctx, id)
+	if err != nil {
+		return TaskNode{}, err
+	}
+	if err := s.requireTask(ctx, tasks[0], RoleViewer); err != nil {
+		return TaskNode{}, err
+	}
+	// невидимые подзадачи выпадают из дерева вместе со своими потомками
+	root := tasks[0]
+	if tasks, err = s.filterReadable(ctx, tasks[1:]); err != nil {
+		return TaskNode{}, err
+	}
+	tasks = append([]Task{root}, tasks...)
+	if err := s.attachTags(ctx, tasks); err != nil {
+		return TaskNode{}, err
+	}
+
+	children := make(map[int64][]Task, len(tasks))
+	for _, t := range tasks[1:] {
+		children[*t.ParentID] = append(children[*t.ParentID], t)
+	}
+
+	var build func(t Task) TaskNode
+	build = func(t Task) TaskNode {
+		node := TaskNode{Task: t}
+		for _, c := range children[t.ID] {
+			node.Children = append(node.Children, build(c))
+		}
+		return node
+	}
+
+	return build(tasks[0]), nil
+}
+
+// checkParent проверяет, что parentID можно назначить родителем задачи taskID
+// (0 - задача ещё не создана) со статусом st. Родитель блокируется: его не
+// завершат, пока не закоммичена открытая подзадача. Смену родителя вызывающий
+// делает под LockTaskTree, иначе встречные переносы вместе дадут цикл
+func (s *Service) checkParent(ctx context.Context, taskID, parentID int64, st TaskStatus) error {
+	if parentID == taskID {
+		return ErrTaskCycle
+	}
+
+	parent, err := s.db.LockTask(ctx, parentID)
+	if err != nil {
+		return err // ErrTaskNotFound -> NotFound
+	}
+	if err := s.requireTask(ctx, parent, RoleEditor); err != nil {
+		return err
+	}
+
+	if taskID != 0 {
+		ancestors, err := s.db.TaskAncestors(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ancestors, taskID) {
+			return ErrTaskCycle
+		}
+	}
+
+	// открытая подзадача у завершённого родителя
+	if s.subtaskRollup && parent.Status == Done && isOpen(st) {
+		return ErrTaskHasOpenSubtasks
+	}
+	return nil
+}
+
+// checkSubtasksDone не даёт завершить задачу с открытыми подзадачами
+func (s *Service) checkSubtasksDone(ctx context.Context, taskID int64, st TaskStatus) error {
+	if !s.subtaskRollup || st != Done {
+		return nil
+	}
+
+	open, err := s.db.CountOpenSubtasks(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrTaskHasOpenSubtasks
+	}
+	return nil
+}
+
+// reparents - патч назначает задаче нового родителя
+func (p TaskPatch) reparents() bool {
+	return p.ParentID != nil && *p.ParentID > 0
+}
+
+func isOpen(st TaskStatus) bool {
+	return st == TODO || st == InProgress
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Tags
+
+func (s *Service) CreateTag(ctx context.Context, name string) (Tag, error) {
+	if strings.TrimSpace(name) == "" {
+		return Tag{}, ErrTagInvalidArgs
+	}
+	return s.db.CreateTag(ctx, name)
+}
+
+func (s *Service) GetTag(ctx context.Context, id int64) (Tag, error) {
+	if id <= 0 {
+		return Tag{}, ErrTagInvalidArgs
+	}
+	return s.db.GetTag(ctx, id)
+}
+
+func (s *Service) ListTags(ctx context.Context, f ListTagsFilter) (TagPage, error) {
+	if f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return TagPage{}, ErrTagInvalidArgs
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListTags(ctx, f)
+	if err != nil {
+		return TagPage{}, err
+	}
+
+	page := TagPage{Tags: items}
+	if len(items) > size {
+		page.Tags = items[:size]
+		last := page.Tags[size-1]
+		page.Next = &TagCursor{Name: last.Name, ID: last.ID}
+	}
+	return page, nil
+}
+
+func (s *Service) RenameTag(ctx context.Context, id int64, name string) (Tag, error) {
+	if id <= 0 || strings.TrimSpace(name) == "" {
+		return Tag{}, ErrTagInvalidArgs
+	}
+	// теги общие для всех категорий, менять их может только сервис
+	if err := requireSystem(ctx); err != nil {
+		return Tag{}, err
+	}
+	return s.db.RenameTag(ctx, id, name)
+}
+
+func (s *Service) DeleteTag(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrTagInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return err
+	}
+	return s.db.DeleteTag(ctx, id)
+}
+
+func (s *Service) MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (Tag, error) {
+	sourceIDs, ok := normalizeIDs(sourceIDs)
+	if !ok || targetID <= 0 || len(sourceIDs) == 0 || slices.Contains(sourceIDs, targetID) {
+		return Tag{}, ErrTagInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return Tag{}, err
+	}
+	return s.db.MergeTags(ctx, targetID, sourceIDs)
+}
+
+// Task tags
+
+func (s *Service) AddTags(ctx context.Context, taskID int64, tagIDs []int64) (Task, error) {
+	tagIDs, ok := normalizeIDs(tagIDs)
+	if !ok || taskID <= 0 || len(tagIDs) == 0 {
+		return Task{}, ErrTaskInvalidArgs
+	}
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.lockTask(ctx, taskID, RoleEditor); err != nil {
+			return err
+		}
+		return s.db.AddTaskTags(ctx, taskID, tagIDs)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return s.GetTask(ctx, taskID)
+}
+
+func (s *Service) RemoveTags(ctx context.Context, taskID int64, tagIDs []int64) (Task, error) {
+	tagIDs, ok := normalizeIDs(tagIDs)
+	if !ok || taskID <= 0 || len(tagIDs) == 0 {
+		return Task{}, ErrTaskInvalidArgs
+	}
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.lockTask(ctx, taskID, RoleEditor); err != nil {
+			return err
+		}
+		return s.db.RemoveTaskTags(ctx, taskID, tagIDs)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return s.GetTask(ctx, taskID)
+}
+
+func (s *Service) withTags(ctx context.Context, t Task) (Task, error) {
+	tasks := []Task{t}
+	if err := s.attachTags(ctx, tasks); err != nil {
+		return Task{}, err
+	}
+	return tasks[0], nil
+}
+
+// attachTags подгружает теги одним запросом для всех задач
+func (s *Service) attachTags(ctx context.Context, tasks []Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+
+	tags, err := s.db.TaskTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].Tags = tags[tasks[i].ID]
+	}
+	return nil
+}
+
+// normalizeIDs убирает дубликаты, false - если есть id <= 0
+func normalizeIDs(ids []int64) ([]int64, bool) {
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, false
+		}
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out, true
+}
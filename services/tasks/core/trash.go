@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Trash
+
+func (s *Service) RestoreTask(ctx context.Context, id int64) (Task, error) {
+	if id <= 0 {
+		return Task{}, ErrTaskInvalidArgs
+	}
+	if err := s.requireAnyTask(ctx, id, RoleEditor); err != nil {
+		return Task{}, err
+	}
+
+	t, err := s.db.RestoreTask(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	s.notifyWatchers()
+	return s.withTags(ctx, t)
+}
+
+func (s *Service) RestoreCategory(ctx context.Context, id int64) (Category, error) {
+	if id <= 0 {
+		return Category{}, ErrCategoryInvalidArgs
+	}
+	if err := s.requireCategory(ctx, id, RoleOwner); err != nil {
+		return Category{}, err
+	}
+	return s.db.RestoreCategory(ctx, id)
+}
+
+func (s *Service) ListDeleted(ctx context.Context, f ListDeletedFilter) (DeletedPage, error) {
+	if f.Kind != TrashTasks && f.Kind != TrashCategories {
+		return DeletedPage{}, ErrTaskInvalidArgs
+	}
+	if f.Limit < 0 || (f.After != nil && (f.After.Kind != f.Kind || f.After.ID <= 0)) {
+		return DeletedPage{}, ErrTaskInvalidArgs
+	}
+
+	f.ReadableBy = readableBy(ctx)
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	var page DeletedPage
+
+	if f.Kind == TrashCategories {
+		items, err := s.db.ListDeletedCategories(ctx, f)
+		if err != nil {
+			return DeletedPage{}, err
+		}
+		page.Categories = items
+		if len(items) > size {
+			page.Categories = items[:size]
+			last := page.Categories[size-1]
+			page.Next = &DeletedCursor{Kind: f.Kind, DeletedAt: *last.DeletedAt, ID: last.ID}
+		}
+		return page, nil
+	}
+
+	items, err := s.db.ListDeletedTasks(ctx, f)
+	if err != nil {
+		return DeletedPage{}, err
+	}
+	page.Tasks = items
+	if len(items) > size {
+		page.Tasks = items[:size]
+		last := page.Tasks[size-1]
+		page.Next = &DeletedCursor{Kind: f.Kind, DeletedAt: *last.DeletedAt, ID: last.ID}
+	}
+
+	if err := s.attachTags(ctx, page.Tasks); err != nil {
+		return DeletedPage{}, err
+	}
+	return page, nil
+}
+
+// PurgeDeleted окончательно удаляет всё, что попало в корзину раньше before.
+// Нулевой before => вся корзина. Корзина общая, поэтому чистит её только сервис
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (PurgeResult, error) {
+	if err := requireSystem(ctx); err != nil {
+		return PurgeResult{}, err
+	}
+	if before.IsZero() {
+		before = time.Now()
+	}
+	res, err := s.db.PurgeDeleted(ctx, before)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	if res.Tasks > 0 {
+		s.notifyWatchers()
+	}
+	return res, nil
+}
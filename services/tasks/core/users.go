@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+)
+
+// Users
+
+// maxUserFieldLen - ограничение длины имени и email в байтах
+const maxUserFieldLen = 255
+
+func (s *Service) CreateUser(ctx context.Context, in NewUser) (User, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	in.Email = strings.TrimSpace(in.Email)
+	if in.Name == "" || len(in.Name) > maxUserFieldLen || len(in.Email) > maxUserFieldLen {
+		return User{}, ErrUserInvalidArgs
+	}
+	// только адрес, без "Имя <адрес>"
+	if addr, err := mail.ParseAddress(in.Email); err != nil || addr.Address != in.Email {
+		return User{}, ErrUserInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return User{}, err
+	}
+
+	return s.db.CreateUser(ctx, in)
+}
+
+func (s *Service) GetUser(ctx context.Context, id int64) (User, error) {
+	if id <= 0 {
+		return User{}, ErrUserInvalidArgs
+	}
+	return s.db.GetUser(ctx, id)
+}
+
+func (s *Service) ListUsers(ctx context.Context, f ListUsersFilter) (UserPage, error) {
+	if f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return UserPage{}, ErrUserInvalidArgs
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListUsers(ctx, f)
+	if err != nil {
+		return UserPage{}, err
+	}
+
+	page := UserPage{Users: items}
+	if len(items) > size {
+		page.Users = items[:size]
+		page.Next = &UserCursor{ID: page.Users[size-1].ID}
+	}
+	return page, nil
+}
+
+// DeleteUser удаляет пользователя; его задачи остаются без исполнителя и автора
+func (s *Service) DeleteUser(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrUserInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return err
+	}
+	if err := s.db.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	s.notifyWatchers()
+	return nil
+}
+
+// AssignTask назначает задачу пользователю userID; version != 0 должна совпасть с текущей
+func (s *Service) AssignTask(ctx context.Context, id, userID, version int64) (Task, error) {
+	if userID <= 0 {
+		return Task{}, ErrUserInvalidArgs
+	}
+	return s.setAssignee(ctx, id, &userID, version)
+}
+
+// UnassignTask снимает исполнителя с задачи; version != 0 должна совпасть с текущей
+func (s *Service) UnassignTask(ctx context.Context, id, version int64) (Task, error) {
+	return s.setAssignee(ctx, id, nil, version)
+}
+
+// Helpers
+
+// setAssignee меняет исполнителя задачи. Повторное назначение того же
+// исполнителя ничего не пишет и не меняет версию
+func (s *Service) setAssignee(ctx context.Context, id int64, userID *int64, version int64) (Task, error) {
+	if id <= 0 || version < 0 {
+		return Task{}, ErrTaskInvalidArgs
+	}
+
+	var updated Task
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		cur, err := s.db.LockTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.requireTask(ctx, cur, RoleEditor); err != nil {
+			return err
+		}
+		if version != 0 && version != cur.Version {
+			return ErrConflict
+		}
+		if sameID(cur.AssigneeID, userID) {
+			updated = cur
+			return nil
+		}
+
+		if userID != nil {
+			if _, err := s.db.LockUser(ctx, *userID); err != nil {
+				return err
+			}
+		}
+
+		updated, err = s.db.AssignTask(ctx, id, userID, cur.Version)
+		return err
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	s.notifyWatchers()
+	return s.withTags(ctx, updated)
+}
+
+// creatorFromContext - автор новой задачи: пользователь из ctx или nil
+func creatorFromContext(ctx context.Context) *int64 {
+	if uid, ok := UserIDFromContext(ctx); ok {
+		return &uid
+	}
+	return nil
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
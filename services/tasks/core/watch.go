@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Watch
+
+// DefaultWatchPollInterval - как часто WatchTasks перечитывает task_events без
+// сигнала от сервиса: так доходят изменения других экземпляров и purge job
+const DefaultWatchPollInterval = 5 * time.Second
+
+// watchBatchSize - сколько событий WatchTasks читает за один запрос
+const watchBatchSize = 100
+
+// WatchTasksFilter - какие изменения задач отдавать подписчику
+type WatchTasksFilter struct {
+	CategoryID *int64
+	Status     *TaskStatus
+
+	// 0 => только события после подписки, иначе продолжить после этого события
+	AfterEventID int64
+}
+
+// TaskChange - событие задачи и её текущее состояние
+type TaskChange struct {
+	Event TaskEvent
+	Task  *Task // Nil, если задача удалена окончательно
+}
+
+// WatchTasks отдаёт в send изменения задач в порядке коммита, пока не
+// отменён ctx или send не вернул ошибку. Источник - журнал task_events,
+// поэтому подписку можно продолжить с id последнего полученного события.
+// Событие транзакции, которая закоммитилась позже транзакции с большим id,
+// приходит после её событий, а не теряется
+func (s *Service) WatchTasks(ctx context.Context, f WatchTasksFilter, send func(TaskChange) error) error {
+	if f.AfterEventID < 0 {
+		return ErrTaskInvalidArgs
+	}
+	if f.CategoryID != nil && *f.CategoryID <= 0 {
+		return ErrTaskInvalidArgs
+	}
+	if f.Status != nil && !isValidStatus(*f.Status) {
+		return ErrTaskInvalidArgs
+	}
+
+	// подписываемся до чтения журнала, чтобы не пропустить сигнал
+	wake, unsubscribe := s.watchers.subscribe()
+	defer unsubscribe()
+
+	after := f.AfterEventID
+	if after == 0 {
+		// "после подписки" - после всех событий, закоммиченных до неё
+		for {
+			n, err := s.db.SequenceTaskEvents(ctx, watchBatchSize)
+			if err != nil {
+				return err
+			}
+			if n < watchBatchSize {
+				break
+			}
+		}
+		last, err := s.db.LastTaskEventID(ctx)
+		if err != nil {
+			return err
+		}
+		after = last
+	}
+
+	ticker := time.NewTicker(s.watchPoll)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		if after, err = s.sendTaskChanges(ctx, f, after, send); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendTaskChanges отдаёт все события после after и возвращает id последнего прочитанного
+func (s *Service) sendTaskChanges(ctx context.Context, f WatchTasksFilter, after int64, send func(TaskChange) error) (int64, error) {
+	for {
+		if _, err := s.db.SequenceTaskEvents(ctx, watchBatchSize); err != nil {
+			return after, err
+		}
+		events, err := s.db.TaskEventsAfter(ctx, after, watchBatchSize)
+		if err != nil {
+			return after, err
+		}
+		if len(events) == 0 {
+			return after, nil
+		}
+
+		ids := make([]int64, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.TaskID)
+		}
+		tasks, err := s.db.TasksByIDs(ctx, ids)
+		if err != nil {
+			return after, err
+		}
+		byID := make(map[int64]*Task, len(tasks))
+		for i := range tasks {
+			byID[tasks[i].ID] = &tasks[i]
+		}
+		// права перечитываются на каждую пачку: роль могли выдать или отозвать
+		canRead, err := s.taskReader(ctx)
+		if err != nil {
+			return after, err
+		}
+
+		for _, e := range events {
+			after = e.ID
+			c := TaskChange{Event: e, Task: byID[e.TaskID]}
+			if !f.matches(c) {
+				continue
+			}
+			// задачу, удалённую насовсем, уже не проверить - пользователю она не отдаётся
+			if canRead != nil && (c.Task == nil || !canRead(*c.Task)) {
+				continue
+			}
+			if err := send(c); err != nil {
+				return after, err
+			}
+		}
+
+		if len(events) < watchBatchSize {
+			return after, nil
+		}
+	}
+}
+
+// notifyWatchers будит подписчиков WatchTasks после записи
+func (s *Service) notifyWatchers() {
+	s.watchers.notify()
+}
+
+// Helpers
+
+// matches - подходит ли изменение под фильтр. Сравниваются текущее состояние
+// задачи и старые значения полей из события, чтобы подписчик узнал и о том,
+// что задача из фильтра вышла
+func (f WatchTasksFilter) matches(c TaskChange) bool {
+	if f.CategoryID != nil {
+		ok := c.Task != nil && c.Task.CategoryID != nil && *c.Task.CategoryID == *f.CategoryID
+		if !ok {
+			var old *int64
+			ok = oldValue(c.Event, "category_id", &old) && old != nil && *old == *f.CategoryID
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if f.Status != nil {
+		ok := c.Task != nil && c.Task.Status == *f.Status
+		if !ok {
+			var old TaskStatus
+			ok = oldValue(c.Event, "status", &old) && old == *f.Status
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// oldValue разбирает в v старое значение поля field из события
+func oldValue(e TaskEvent, field string, v any) bool {
+	for _, c := range e.Changes {
+		if c.Field == field {
+			return json.Unmarshal(c.Old, v) == nil
+		}
+	}
+	return false
+}
+
+// broadcaster - in-process шина: будит всех подписчиков без передачи данных
+type broadcaster struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{subs: make(map[chan struct{}]struct{})}
+}
+
+func (b *broadcaster) subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}
+}
+
+func (b *broadcaster) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		// подписчик ещё не забрал прошлый сигнал - второй не нужен
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhooks
+
+const (
+	// DefaultWebhookBatchSize - сколько доставок worker отправляет за один проход, параллельно
+	DefaultWebhookBatchSize = 20
+	// DefaultWebhookMaxAttempts - после стольких неудачных попыток доставка становится dead
+	DefaultWebhookMaxAttempts = 10
+	// DefaultWebhookMaxBackoff - максимальная пауза перед повторной доставкой
+	DefaultWebhookMaxBackoff = time.Hour
+	// DefaultWebhookTimeout - сколько ждать ответа получателя
+	DefaultWebhookTimeout = 10 * time.Second
+
+	// deliveryMarkTime - запас аренды на запись результата одной доставки,
+	// deliveryLeaseMargin - на взятие доставок и паузы планировщика
+	deliveryMarkTime    = 100 * time.Millisecond
+	deliveryLeaseMargin = 30 * time.Second
+
+	maxWebhookURLLen    = 2048
+	maxWebhookSecretLen = 256
+	webhookSecretBytes  = 32
+)
+
+// CreateWebhook регистрирует вебхук. Пустой секрет генерируется, секрет
+// возвращается в ответе и дальше наружу не отдаётся
+func (s *Service) CreateWebhook(ctx context.Context, in NewWebhook) (Webhook, error) {
+	if err := checkNewWebhook(&in); err != nil {
+		return Webhook{}, err
+	}
+	if err := requireSystem(ctx); err != nil {
+		return Webhook{}, err
+	}
+
+	if in.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return Webhook{}, err
+		}
+		in.Secret = secret
+	}
+
+	var w Webhook
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		if in.CategoryID != nil {
+			if _, err := s.db.LockCategory(ctx, *in.CategoryID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		w, err = s.db.CreateWebhook(ctx, in)
+		return err
+	})
+	if err != nil {
+		return Webhook{}, err
+	}
+	return w, nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, id int64) (Webhook, error) {
+	if id <= 0 {
+		return Webhook{}, ErrWebhookInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return Webhook{}, err
+	}
+	return s.db.GetWebhook(ctx, id)
+}
+
+func (s *Service) ListWebhooks(ctx context.Context, f ListWebhooksFilter) (WebhookPage, error) {
+	if f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return WebhookPage{}, ErrWebhookInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return WebhookPage{}, err
+	}
+
+	// берём на одну запись больше, чтобы понять, есть ли следующая страница
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListWebhooks(ctx, f)
+	if err != nil {
+		return WebhookPage{}, err
+	}
+
+	page := WebhookPage{Webhooks: items}
+	if len(items) > size {
+		page.Webhooks = items[:size]
+		page.Next = &WebhookCursor{ID: page.Webhooks[size-1].ID}
+	}
+	return page, nil
+}
+
+// DeleteWebhook удаляет вебхук вместе с его доставками
+func (s *Service) DeleteWebhook(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrWebhookInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return err
+	}
+	return s.db.DeleteWebhook(ctx, id)
+}
+
+// ListDeliveries возвращает доставки от новых к старым
+func (s *Service) ListDeliveries(ctx context.Context, f ListDeliveriesFilter) (DeliveryPage, error) {
+	if f.WebhookID < 0 || f.Limit < 0 || (f.After != nil && f.After.ID <= 0) {
+		return DeliveryPage{}, ErrWebhookInvalidArgs
+	}
+	if f.Status != nil && (*f.Status < DeliveryPending || *f.Status > DeliveryDead) {
+		return DeliveryPage{}, ErrWebhookInvalidArgs
+	}
+	if err := requireSystem(ctx); err != nil {
+		return DeliveryPage{}, err
+	}
+	if f.WebhookID != 0 {
+		if _, err := s.db.GetWebhook(ctx, f.WebhookID); err != nil {
+			return DeliveryPage{}, err
+		}
+	}
+
+	size := pageSize(f.Limit)
+	f.Limit = size + 1
+
+	items, err := s.db.ListDeliveries(ctx, f)
+	if err != nil {
+		return DeliveryPage{}, err
+	}
+
+	page := DeliveryPage{Deliveries: items}
+	if len(items) > size {
+		page.Deliveries = items[:size]
+		page.Next = &DeliveryCursor{ID: page.Deliveries[size-1].ID}
+	}
+	return page, nil
+}
+
+// DeliveryResult - итог одного прохода доставки вебхуков
+type DeliveryResult struct {
+	Delivered int
+	Failed    int
+	Dead      int
+}
+
+// DeliverWebhooks за один проход отправляет через sender до limit готовых доставок.
+// Ответ 2xx => доставлено, иначе повтор с растущей паузой, а после
+// webhookMaxAttempts попыток доставка становится dead
+func (s *Service) DeliverWebhooks(ctx context.Context, sender WebhookSender, limit int) (DeliveryResult, error) {
+	if limit <= 0 {
+		limit = DefaultWebhookBatchSize
+	}
+
+	pending, err := s.db.ClaimDeliveries(ctx, limit, deliveryLease(s.webhookTimeout, limit))
+	if err != nil {
+		return DeliveryResult{}, err
+	}
+
+	// отправки обрываются по таймауту, чтобы проход уложился в аренду
+	sendCtx, cancel := context.WithTimeout(ctx, s.webhookTimeout)
+	defer cancel()
+
+	// один медленный получатель не должен задерживать остальных
+	type sent struct {
+		code int
+		err  error
+	}
+	results := make([]sent, len(pending))
+
+	var wg sync.WaitGroup
+	for i, d := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := sender.Send(sendCtx, d)
+			results[i] = sent{code: code, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var res DeliveryResult
+	for i, d := range pending {
+		r := results[i]
+		if r.err == nil && r.code >= 200 && r.code < 300 {
+			if err := s.db.MarkDeliveryDelivered(ctx, d.ID, r.code); err != nil {
+				return res, err
+			}
+			res.Delivered++
+			continue
+		}
+
+		reason := fmt.Sprintf("unexpected status %d", r.code)
+		if r.err != nil {
+			reason = r.err.Error()
+		}
+
+		dead := d.Attempts+1 >= s.webhookMaxAttempts
+		if err := s.db.MarkDeliveryFailed(ctx, d.ID, r.code, backoff(d.Attempts, s.webhookMaxBackoff), lastError(reason), dead); err != nil {
+			return res, err
+		}
+		if dead {
+			res.Dead++
+		} else {
+			res.Failed++
+		}
+	}
+	return res, nil
+}
+
+// PurgeDeliveries удаляет завершённые доставки (delivered и dead), созданные раньше before
+func (s *Service) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	return s.db.PurgeDeliveries(ctx, before)
+}
+
+// Helpers
+
+// deliveryLease - аренда прохода из batch доставок: отправки идут параллельно и
+// не дольше timeout, затем результат каждой записывается отдельным запросом
+func deliveryLease(timeout time.Duration, batch int) time.Duration {
+	return timeout + time.Duration(batch)*deliveryMarkTime + deliveryLeaseMargin
+}
+
+// checkNewWebhook проверяет поля вебхука и убирает повторы типов событий
+func checkNewWebhook(in *NewWebhook) error {
+	in.URL = strings.TrimSpace(in.URL)
+	if in.URL == "" || len(in.URL) > maxWebhookURLLen {
+		return ErrWebhookInvalidArgs
+	}
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWebhookInvalidArgs
+	}
+
+	if len(in.Secret) > maxWebhookSecretLen {
+		return ErrWebhookInvalidArgs
+	}
+
+	for _, t := range in.EventTypes {
+		if !outboxTypes[t] {
+			return ErrWebhookInvalidArgs
+		}
+	}
+	slices.Sort(in.EventTypes)
+	in.EventTypes = slices.Compact(in.EventTypes)
+
+	if in.CategoryID != nil && *in.CategoryID <= 0 {
+		return ErrWebhookInvalidArgs
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
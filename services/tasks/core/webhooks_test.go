@@ -0,0 +1,99 @@
+package core_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"task-manager-microservice/tasks/adapters/webhook"
+	"task-manager-microservice/tasks/core"
+)
+
+// deliveriesDB отдаёт доставки один раз и запоминает, чем они закончились
+type deliveriesDB struct {
+	core.DB
+
+	pending []core.PendingDelivery
+	lease   time.Duration
+	codes   map[int64]int
+	dead    map[int64]string
+}
+
+func (db *deliveriesDB) ClaimDeliveries(_ context.Context, _ int, lease time.Duration) ([]core.PendingDelivery, error) {
+	claimed := db.pending
+	db.pending, db.lease = nil, lease
+	return claimed, nil
+}
+
+func (db *deliveriesDB) MarkDeliveryDelivered(_ context.Context, id int64, code int) error {
+	db.codes[id] = code
+	return nil
+}
+
+func (db *deliveriesDB) MarkDeliveryFailed(_ context.Context, id int64, code int, _ time.Duration, reason string, dead bool) error {
+	db.codes[id] = code
+	if dead {
+		db.dead[id] = reason
+	}
+	return nil
+}
+
+func newDeliveriesDB(urls ...string) *deliveriesDB {
+	db := &deliveriesDB{codes: map[int64]int{}, dead: map[int64]string{}}
+	for i, url := range urls {
+		id := int64(i + 1)
+		db.pending = append(db.pending, core.PendingDelivery{
+			WebhookDelivery: core.WebhookDelivery{ID: id, EventID: id, EventType: core.OutboxTaskCreated, Payload: []byte(`{}`)},
+			URL:             url,
+			Secret:          "secret",
+		})
+	}
+	return db
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := webhook.Verify("secret", r, []byte(`{}`), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	db := newDeliveriesDB(ok.URL, failing.URL)
+	svc := core.NewService(db, core.WithWebhookRetry(1, time.Minute), core.WithWebhookTimeout(time.Second))
+
+	res, err := svc.DeliverWebhooks(context.Background(), webhook.NewSender(time.Second, webhook.AllowPrivateNetworks()), 10)
+	if err != nil {
+		t.Fatalf("DeliverWebhooks: %v", err)
+	}
+	if want := (core.DeliveryResult{Delivered: 1, Dead: 1}); res != want {
+		t.Fatalf("DeliverWebhooks = %+v, want %+v", res, want)
+	}
+	if db.codes[1] != http.StatusOK || db.codes[2] != http.StatusServiceUnavailable {
+		t.Errorf("response codes %v", db.codes)
+	}
+	if db.lease <= time.Second {
+		t.Errorf("lease %v does not cover the send timeout", db.lease)
+	}
+}
+
+func TestDeliverWebhooksBlockedAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	db := newDeliveriesDB(srv.URL)
+	svc := core.NewService(db, core.WithWebhookRetry(1, time.Minute))
+
+	if _, err := svc.DeliverWebhooks(context.Background(), webhook.NewSender(time.Second), 10); err != nil {
+		t.Fatalf("DeliverWebhooks: %v", err)
+	}
+	if reason, ok := db.dead[1]; !ok || reason == "" || db.codes[1] != 0 {
+		t.Errorf("delivery codes %v, dead %v", db.codes, db.dead)
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Workflow - разрешённые переходы статусов задачи: from -> набор to.
+// Nil Workflow разрешает любые переходы
+type Workflow map[TaskStatus]map[TaskStatus]bool
+
+// DefaultWorkflow - таблица переходов, когда своя не настроена: закрытую задачу
+// можно вернуть в работу, архивную - только в done
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		TODO:       {InProgress: true, Done: true, Archived: true},
+		InProgress: {TODO: true, Done: true, Archived: true},
+		Done:       {InProgress: true, Archived: true},
+		Archived:   {Done: true},
+	}
+}
+
+// Allows сообщает, можно ли перевести задачу из from в to.
+// Переход в тот же статус всегда разрешён
+func (w Workflow) Allows(from, to TaskStatus) bool {
+	if w == nil || from == to {
+		return true
+	}
+	return w[from][to]
+}
+
+var statusNames = map[string]TaskStatus{
+	"todo":        TODO,
+	"in_progress": InProgress,
+	"done":        Done,
+	"archived":    Archived,
+}
+
+// ParseStatus разбирает имя статуса из конфига: todo, in_progress, done, archived
+func ParseStatus(name string) (TaskStatus, error) {
+	st, ok := statusNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return TODO, fmt.Errorf("unknown status %q", name)
+	}
+	return st, nil
+}
+
+// ParseWorkflow собирает Workflow из таблицы переходов конфига.
+// Пустая таблица => DefaultWorkflow
+func ParseWorkflow(transitions map[string][]string) (Workflow, error) {
+	if len(transitions) == 0 {
+		return DefaultWorkflow(), nil
+	}
+
+	w := make(Workflow, len(transitions))
+	for fromName, toNames := range transitions {
+		from, err := ParseStatus(fromName)
+		if err != nil {
+			return nil, err
+		}
+		if w[from] == nil {
+			w[from] = make(map[TaskStatus]bool, len(toNames))
+		}
+		for _, toName := range toNames {
+			to, err := ParseStatus(toName)
+			if err != nil {
+				return nil, err
+			}
+			w[from][to] = true
+		}
+	}
+	return w, nil
+}
+
+// checkTransition проверяет переход статуса по таблице сервиса
+func (s *Service) checkTransition(from, to TaskStatus) error {
+	if !s.workflow.Allows(from, to) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"task-manager-microservice/tasks/core"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	w, err := core.ParseWorkflow(nil)
+	if err != nil {
+		t.Fatalf("ParseWorkflow(nil): %v", err)
+	}
+	if !reflect.DeepEqual(w, core.DefaultWorkflow()) {
+		t.Fatalf("ParseWorkflow(nil) = %v, want DefaultWorkflow", w)
+	}
+
+	w, err = core.ParseWorkflow(map[string][]string{
+		"todo":         {"In_Progress"},
+		" in_progress": {"done", "todo"},
+	})
+	if err != nil {
+		t.Fatalf("ParseWorkflow: %v", err)
+	}
+	tests := []struct {
+		from, to core.TaskStatus
+		ok       bool
+	}{
+		{core.TODO, core.InProgress, true},
+		{core.TODO, core.Done, false},
+		{core.InProgress, core.Done, true},
+		{core.InProgress, core.TODO, true},
+		{core.Done, core.TODO, false}, // статуса нет в таблице
+		{core.Done, core.Done, true},
+	}
+	for _, tt := range tests {
+		if got := w.Allows(tt.from, tt.to); got != tt.ok {
+			t.Errorf("Allows(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.ok)
+		}
+	}
+
+	for _, bad := range []map[string][]string{
+		{"todo": {"closed"}},
+		{"closed": {"todo"}},
+	} {
+		if _, err := core.ParseWorkflow(bad); err == nil {
+			t.Errorf("ParseWorkflow(%v) accepted an unknown status", bad)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -10,10 +11,14 @@ import (
 	"os/signal"
 	"syscall"
 	taskspb "task-manager-microservice/proto/tasks"
+	"task-manager-microservice/tasks/adapters/auth"
 	"task-manager-microservice/tasks/adapters/db"
 	taskgrpc "task-manager-microservice/tasks/adapters/grpc"
+	"task-manager-microservice/tasks/adapters/publisher"
+	"task-manager-microservice/tasks/adapters/webhook"
 	"task-manager-microservice/tasks/config"
 	"task-manager-microservice/tasks/core"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -60,8 +65,55 @@ func run(cfg config.Config, log *slog.Logger) error {
 		return fmt.Errorf("failed to migrate db: %v", err)
 	}
 
+	workflow, err := core.ParseWorkflow(cfg.StatusTransitions)
+	if err != nil {
+		return fmt.Errorf("invalid status_transitions: %v", err)
+	}
+	if err := core.CheckBootstrapAPIKey(cfg.AuthBootstrapAPIKey); err != nil {
+		return fmt.Errorf("invalid auth_bootstrap_api_key: %v", err)
+	}
+
 	// service
-	tasksService := core.NewService(storage)
+	tasksService := core.NewService(storage,
+		core.WithSubtaskRollup(cfg.SubtaskRollup),
+		core.WithWorkflow(workflow),
+		core.WithIdempotencyTTL(cfg.IdempotencyTTL),
+		core.WithWatchPollInterval(cfg.WatchPollInterval),
+		core.WithOutboxMaxBackoff(cfg.OutboxMaxBackoff),
+		core.WithOutboxLease(cfg.OutboxLease),
+		core.WithWebhookTimeout(cfg.WebhookTimeout),
+		core.WithWebhookRetry(cfg.WebhookMaxAttempts, cfg.WebhookMaxBackoff),
+		core.WithBootstrapAPIKey(cfg.AuthBootstrapAPIKey),
+	)
+
+	// outbox relay
+	pub, err := publisher.New(cfg.OutboxPublisher, cfg.OutboxFile)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox publisher: %v", err)
+	}
+	if pub != nil {
+		defer func() {
+			if err := pub.Close(); err != nil {
+				log.Error("failed to close outbox publisher", "error", err)
+			}
+		}()
+		go runRelay(ctx, log, tasksService, pub, cfg.OutboxBatchSize, cfg.OutboxRelayInterval)
+	}
+
+	// webhook deliveries
+	if cfg.WebhookDeliveryInterval > 0 {
+		var opts []webhook.SenderOption
+		if cfg.WebhookAllowPrivate {
+			opts = append(opts, webhook.AllowPrivateNetworks())
+		}
+		sender := webhook.NewSender(cfg.WebhookTimeout, opts...)
+		go runWebhooks(ctx, log, tasksService, sender, cfg.WebhookBatchSize, cfg.WebhookDeliveryInterval)
+	}
+
+	// purge job
+	if cfg.PurgeInterval > 0 {
+		go runPurge(ctx, log, tasksService, cfg, pub != nil)
+	}
 
 	// grpc
 	listener, err := net.Listen("tcp", cfg.Address)
@@ -69,19 +121,53 @@ func run(cfg config.Config, log *slog.Logger) error {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// аутентификация идёт первой: ActorInterceptor не должен брать автора из заголовков
+	unary := []grpc.UnaryServerInterceptor{taskgrpc.ActorInterceptor, taskgrpc.IdempotencyInterceptor}
+	var stream []grpc.StreamServerInterceptor
+	if cfg.AuthEnabled {
+		authn, err := makeAuth(log, tasksService, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to set up auth: %v", err)
+		}
+		unary = append([]grpc.UnaryServerInterceptor{authn.Unary}, unary...)
+		stream = append(stream, authn.Stream)
+	} else {
+		log.Warn("authentication is disabled")
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
 
 	// grpc handler
 	handler := taskgrpc.NewServer(log, tasksService)
 
 	taskspb.RegisterCategoriesServiceServer(s, handler)
 	taskspb.RegisterTasksServiceServer(s, handler)
+	taskspb.RegisterTagsServiceServer(s, handler)
+	taskspb.RegisterTrashServiceServer(s, handler)
+	taskspb.RegisterWebhooksServiceServer(s, handler)
+	taskspb.RegisterUsersServiceServer(s, handler)
+	taskspb.RegisterApiKeysServiceServer(s, handler)
 	reflection.Register(s)
 
 	go func() {
 		<-ctx.Done()
 		log.Debug("shutting down tasks-service server")
-		s.GracefulStop()
+
+		// GracefulStop ждёт все RPC, а WatchTasks сам не завершается
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(cfg.ShutdownTimeout):
+			log.Debug("shutdown timeout, closing open streams")
+			s.Stop()
+		}
 	}()
 
 	log.Info("tasks-service gRPC server is running", "address", cfg.Address)
@@ -94,6 +180,161 @@ func run(cfg config.Config, log *slog.Logger) error {
 	return nil
 }
 
+// makeAuth собирает аутентификацию из конфига. Без секрета и ключа JWT сервис
+// не стартует: включённая аутентификация не должна молча остаться без JWT
+func makeAuth(log *slog.Logger, svc *core.Service, cfg config.Config) (*taskgrpc.Auth, error) {
+	if cfg.AuthJWTSecret == "" && cfg.AuthJWTPublicKeyFile == "" {
+		return nil, errors.New("auth is enabled, but neither auth_jwt_secret nor auth_jwt_public_key_file is set")
+	}
+
+	var pemKey []byte
+	if cfg.AuthJWTPublicKeyFile != "" {
+		b, err := os.ReadFile(cfg.AuthJWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwt public key: %w", err)
+		}
+		pemKey = b
+	}
+	verifier, err := auth.NewVerifier(cfg.AuthJWTSecret, pemKey, cfg.AuthJWTIssuer, cfg.AuthJWTAudience,
+		auth.WithSystemSubjects(cfg.AuthSystemSubjects...),
+		auth.WithSystemScope(cfg.AuthSystemScope),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AuthBootstrapAPIKey != "" {
+		log.Warn("bootstrap api key is enabled, remove it once the first user has a key")
+	}
+
+	public := taskgrpc.PublicMethods(cfg.AuthPublicPing, cfg.AuthPublicReflection)
+	return taskgrpc.NewAuth(log, svc, verifier, public), nil
+}
+
+// runPurge раз в PurgeInterval окончательно удаляет то, что лежит в корзине дольше
+// TrashRetention, события outbox и завершённые доставки вебхуков старше своих
+// retention (0 => не чистит) и просроченные ключи идемпотентности. Без relay
+// события outbox никто не публикует, и они удаляются неопубликованными
+func runPurge(ctx context.Context, log *slog.Logger, svc *core.Service, cfg config.Config, relay bool) {
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if cfg.TrashRetention > 0 {
+				res, err := svc.PurgeDeleted(ctx, time.Now().Add(-cfg.TrashRetention))
+				if err != nil {
+					log.Error("failed to purge trash", "error", err)
+				} else if res.Tasks > 0 || res.Categories > 0 {
+					log.Info("trash purged", "tasks", res.Tasks, "categories", res.Categories)
+				}
+			}
+
+			n, err := svc.PurgeIdempotencyKeys(ctx)
+			if err != nil {
+				log.Error("failed to purge idempotency keys", "error", err)
+			} else if n > 0 {
+				log.Debug("idempotency keys purged", "count", n)
+			}
+
+			if cfg.OutboxRetention > 0 {
+				n, err := svc.PurgeOutbox(ctx, time.Now().Add(-cfg.OutboxRetention), !relay)
+				if err != nil {
+					log.Error("failed to purge outbox", "error", err)
+				} else if n > 0 {
+					log.Debug("outbox purged", "count", n)
+				}
+			}
+
+			if cfg.WebhookDeliveryRetention > 0 {
+				n, err := svc.PurgeDeliveries(ctx, time.Now().Add(-cfg.WebhookDeliveryRetention))
+				if err != nil {
+					log.Error("failed to purge webhook deliveries", "error", err)
+				} else if n > 0 {
+					log.Debug("webhook deliveries purged", "count", n)
+				}
+			}
+		}
+	}
+}
+
+// runRelay раз в interval отправляет события outbox через pub. Полный проход
+// означает, что события могли остаться, и следующий начинается сразу
+func runRelay(ctx context.Context, log *slog.Logger, svc *core.Service, pub core.Publisher, batch int, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if batch <= 0 {
+		batch = core.DefaultOutboxBatchSize
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		res, err := svc.RelayOutbox(ctx, pub, batch)
+		if err != nil && ctx.Err() == nil {
+			log.Error("failed to relay outbox", "error", err)
+		}
+		if res.Failed > 0 {
+			log.Warn("outbox events failed, will retry", "published", res.Published, "failed", res.Failed)
+		} else if res.Published > 0 {
+			log.Debug("outbox events published", "count", res.Published)
+		}
+
+		next := interval
+		if err == nil && res.Published+res.Failed >= batch {
+			next = 0
+		}
+		timer.Reset(next)
+	}
+}
+
+// runWebhooks раз в interval отправляет доставки вебхуков через sender;
+// после полного прохода следующий начинается сразу
+func runWebhooks(ctx context.Context, log *slog.Logger, svc *core.Service, sender core.WebhookSender, batch int, interval time.Duration) {
+	if batch <= 0 {
+		batch = core.DefaultWebhookBatchSize
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		res, err := svc.DeliverWebhooks(ctx, sender, batch)
+		if err != nil && ctx.Err() == nil {
+			log.Error("failed to deliver webhooks", "error", err)
+		}
+		if res.Dead > 0 {
+			log.Warn("webhook deliveries dead", "count", res.Dead)
+		}
+		if res.Delivered > 0 || res.Failed > 0 {
+			log.Debug("webhooks delivered", "delivered", res.Delivered, "failed", res.Failed)
+		}
+
+		next := interval
+		if err == nil && res.Delivered+res.Failed+res.Dead >= batch {
+			next = 0
+		}
+		timer.Reset(next)
+	}
+}
+
 func mustMakeLogger(levelStr string) *slog.Logger {
 	var level slog.Level
 	switch levelStr {